@@ -62,24 +62,10 @@ const CategoryManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error('No authentication token');
-      }
-
-      const response = await fetch('https://api.sekawan-grup.com/api/categories', {
-        method: 'GET',
-        headers: {
-          'Content-Type': 'application/json',
-          'Authorization': `Bearer ${token}`
-        }
-      });
-
-      if (!response.ok) {
-        throw new Error('Failed to fetch categories');
-      }
-
-      const data = await response.json();
+      const data = await AuthService.fetchAll<Category>(
+        'https://api.sekawan-grup.com/api/categories?per_page=100',
+        'Failed to fetch categories'
+      );
       setCategories(data);
     } catch (err) {
       setError(err instanceof Error ? err.message : 'An unexpected error occurred');
@@ -96,16 +82,10 @@ const CategoryManager: React.FC = () => {
     }
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error('No authentication token');
-      }
-
-      const response = await fetch('https://api.sekawan-grup.com/api/category', {
+      const response = await AuthService.fetch('https://api.sekawan-grup.com/api/category', {
         method: 'POST',
         headers: {
-          'Content-Type': 'application/json',
-          'Authorization': `Bearer ${token}`
+          'Content-Type': 'application/json'
         },
         body: JSON.stringify(newCategory)
       });
@@ -129,16 +109,10 @@ const CategoryManager: React.FC = () => {
     if (!editingCategory) return;
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error('No authentication token');
-      }
-
-      const response = await fetch(`https://api.sekawan-grup.com/api/category/${editingCategory.id}`, {
+      const response = await AuthService.fetch(`https://api.sekawan-grup.com/api/category/${editingCategory.id}`, {
         method: 'PATCH',
         headers: {
-          'Content-Type': 'application/json',
-          'Authorization': `Bearer ${token}`
+          'Content-Type': 'application/json'
         },
         body: JSON.stringify({
           name: editingCategory.name,
@@ -163,16 +137,10 @@ const CategoryManager: React.FC = () => {
   // Fungsi untuk menghapus kategori
   const handleDeleteCategory = async (categoryId: number) => {
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error('No authentication token');
-      }
-
-      const response = await fetch(`https://api.sekawan-grup.com/api/category/${categoryId}`, {
+      const response = await AuthService.fetch(`https://api.sekawan-grup.com/api/category/${categoryId}`, {
         method: 'DELETE',
         headers: {
-          'Content-Type': 'application/json',
-          'Authorization': `Bearer ${token}`
+          'Content-Type': 'application/json'
         }
       });
 
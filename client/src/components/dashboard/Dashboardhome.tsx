@@ -27,6 +27,8 @@ interface Link {
 const DashboardHome: React.FC = () => {
   const [categories, setCategories] = useState<Category[]>([]);
   const [links, setLinks] = useState<Link[]>([]);
+  const [totalLinks, setTotalLinks] = useState<number>(0);
+  const [activeLinks, setActiveLinks] = useState<number>(0);
   const [loading, setLoading] = useState<boolean>(true);
   const [error, setError] = useState<string | null>(null);
 
@@ -35,48 +37,37 @@ const DashboardHome: React.FC = () => {
     const fetchData = async () => {
       setLoading(true);
       try {
-        const token = AuthService.getToken();
-        if (!token) {
-          throw new Error("No authentication token");
-        }
-
         // Fetch categories
-        const categoriesResponse = await fetch(
-          "https://api.sekawan-grup.com/api/categories",
-          {
-            method: "GET",
-            headers: {
-              "Content-Type": "application/json",
-              Authorization: `Bearer ${token}`,
-            },
-          }
+        const categoriesData = await AuthService.fetchAll<Category>(
+          "https://api.sekawan-grup.com/api/categories?per_page=100",
+          "Failed to fetch categories"
         );
-
-        if (!categoriesResponse.ok) {
-          throw new Error("Failed to fetch categories");
-        }
-
-        const categoriesData = await categoriesResponse.json();
         setCategories(categoriesData);
 
-        // Fetch links
-        const linksResponse = await fetch(
-          "https://api.sekawan-grup.com/api/links/all",
-          {
-            method: "GET",
-            headers: {
-              "Content-Type": "application/json",
-              Authorization: `Bearer ${token}`,
-            },
-          }
+        // Fetch the newest links and the link totals
+        const [linksResponse, activeResponse] = await Promise.all(
+          [
+            "https://api.sekawan-grup.com/api/links/all?sort=-created_at&per_page=5",
+            "https://api.sekawan-grup.com/api/links/all?is_active=true&per_page=1",
+          ].map((url) =>
+            AuthService.fetch(url, {
+              method: "GET",
+              headers: {
+                "Content-Type": "application/json",
+              },
+            })
+          )
         );
 
-        if (!linksResponse.ok) {
+        if (!linksResponse.ok || !activeResponse.ok) {
           throw new Error("Failed to fetch links");
         }
 
-        const linksData = await linksResponse.json();
-        setLinks(linksData);
+        const linksPage = await linksResponse.json();
+        const activePage = await activeResponse.json();
+        setLinks(linksPage.data);
+        setTotalLinks(linksPage.total);
+        setActiveLinks(activePage.total);
       } catch (err) {
         console.error("Error fetching data:", err);
         setError(err instanceof Error ? err.message : "An unexpected error occurred");
@@ -110,7 +101,7 @@ const DashboardHome: React.FC = () => {
                 <CardDescription className="text-luxury-700">Semua produk di semua marketplace</CardDescription>
               </CardHeader>
               <CardContent className="pt-4">
-                <p className="text-3xl font-bold text-white">{links.length}</p>
+                <p className="text-3xl font-bold text-white">{totalLinks}</p>
               </CardContent>
             </Card>
             
@@ -132,10 +123,10 @@ const DashboardHome: React.FC = () => {
               </CardHeader>
               <CardContent className="pt-4">
                 <p className="text-3xl font-bold text-white">
-                  {links.filter(link => link.is_active).length}
+                  {activeLinks}
                 </p>
                 <p className="text-sm text-luxury-700 mt-2">
-                  {((links.filter(link => link.is_active).length / links.length) * 100).toFixed(0)}% dari total produk
+                  {totalLinks > 0 ? ((activeLinks / totalLinks) * 100).toFixed(0) : 0}% dari total produk
                 </p>
               </CardContent>
             </Card>
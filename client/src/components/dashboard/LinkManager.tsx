@@ -37,8 +37,39 @@ interface Link {
   is_active: boolean;
   category_id: number;
   order?: number;
+  publish_at?: string | null;
+  unpublish_at?: string | null;
+  status?: string;
 }
 
+// Label jadwal tayang, hanya untuk link yang punya jadwal
+const scheduleBadges: Record<string, { label: string; className: string }> = {
+  scheduled: {
+    label: "Terjadwal",
+    className: "bg-blue-500/10 text-blue-400 hover:bg-blue-500/20 border-blue-500/30",
+  },
+  live: {
+    label: "Tayang",
+    className: "bg-gold/10 text-gold hover:bg-gold/20 border-gold/30",
+  },
+  expired: {
+    label: "Kedaluwarsa",
+    className: "bg-luxury-700/10 text-luxury-700 hover:bg-luxury-700/20 border-luxury-700/30",
+  },
+};
+
+// Ubah waktu ISO dari server ke nilai input datetime-local
+const toLocalInput = (value?: string | null) => {
+  if (!value) return "";
+  const date = new Date(value);
+  const offset = date.getTimezoneOffset() * 60000;
+  return new Date(date.getTime() - offset).toISOString().slice(0, 16);
+};
+
+// Ubah nilai input datetime-local ke waktu ISO, kosong berarti tanpa batas
+const fromLocalInput = (value: string) =>
+  value ? new Date(value).toISOString() : null;
+
 // Komponen untuk Mengelola Link Produk
 const LinkManager: React.FC = () => {
   // State untuk menyimpan kategori
@@ -60,6 +91,8 @@ const LinkManager: React.FC = () => {
     price: 0,
     price_str: "",
     is_active: true,
+    publish_at: null,
+    unpublish_at: null,
   });
 
   // State untuk validation
@@ -82,27 +115,10 @@ const LinkManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error("No authentication token");
-      }
-
-      const response = await fetch(
-        "https://api.sekawan-grup.com/api/categories",
-        {
-          method: "GET",
-          headers: {
-            "Content-Type": "application/json",
-            Authorization: `Bearer ${token}`,
-          },
-        }
+      const data = await AuthService.fetchAll<Category>(
+        "https://api.sekawan-grup.com/api/categories?per_page=100",
+        "Failed to fetch categories"
       );
-
-      if (!response.ok) {
-        throw new Error("Failed to fetch categories");
-      }
-
-      const data = await response.json();
       setCategories(data);
 
       // If we have categories, set the first one as active
@@ -124,28 +140,11 @@ const LinkManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error("No authentication token");
-      }
-
       // Using the getAllLinks endpoint and filter by category
-      const response = await fetch(
-        "https://api.sekawan-grup.com/api/links/all",
-        {
-          method: "GET",
-          headers: {
-            "Content-Type": "application/json",
-            Authorization: `Bearer ${token}`,
-          },
-        }
+      const allLinks = await AuthService.fetchAll<Link>(
+        `https://api.sekawan-grup.com/api/links/all?category_id=${categoryId}&sort=order&per_page=100`,
+        "Failed to fetch links"
       );
-
-      if (!response.ok) {
-        throw new Error("Failed to fetch links");
-      }
-
-      const allLinks = await response.json();
       
       // Filter links by category_id
       const categoryLinks = allLinks.filter(
@@ -171,18 +170,12 @@ const LinkManager: React.FC = () => {
 
       // Fallback to the original endpoint if the all links endpoint fails
       try {
-        const token = AuthService.getToken();
-        if (!token) {
-          throw new Error("No authentication token");
-        }
-
-        const response = await fetch(
+        const response = await AuthService.fetch(
           `https://api.sekawan-grup.com/api/categories/${categoryId}/links`,
           {
             method: "GET",
             headers: {
               "Content-Type": "application/json",
-              Authorization: `Bearer ${token}`,
             },
           }
         );
@@ -246,18 +239,12 @@ const LinkManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error("No authentication token");
-      }
-
-      const response = await fetch(
+      const response = await AuthService.fetch(
         `https://api.sekawan-grup.com/api/categories/${activeCategory.id}/links`,
         {
           method: "POST",
           headers: {
             "Content-Type": "application/json",
-            Authorization: `Bearer ${token}`,
           },
           body: JSON.stringify({
             ...newLink,
@@ -282,6 +269,8 @@ const LinkManager: React.FC = () => {
         price: 0,
         price_str: "",
         is_active: true,
+        publish_at: null,
+        unpublish_at: null,
       });
     } catch (err) {
       setError(err instanceof Error ? err.message : "Gagal membuat link");
@@ -306,18 +295,12 @@ const LinkManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error("No authentication token");
-      }
-
-      const response = await fetch(
+      const response = await AuthService.fetch(
         `https://api.sekawan-grup.com/api/categories/${activeCategory.id}/links/${editingLink.id}`,
         {
           method: "PATCH",
           headers: {
             "Content-Type": "application/json",
-            Authorization: `Bearer ${token}`,
           },
           body: JSON.stringify({
             ...editingLink,
@@ -364,18 +347,12 @@ const LinkManager: React.FC = () => {
     setError(null);
 
     try {
-      const token = AuthService.getToken();
-      if (!token) {
-        throw new Error("No authentication token");
-      }
-
-      const response = await fetch(
+      const response = await AuthService.fetch(
         `https://api.sekawan-grup.com/api/categories/${activeCategory.id}/links/${link.id}`,
         {
           method: "DELETE",
           headers: {
             "Content-Type": "application/json",
-            Authorization: `Bearer ${token}`,
           },
         }
       );
@@ -511,6 +488,42 @@ const LinkManager: React.FC = () => {
                     className="bg-luxury-100 border-gold/20 text-white placeholder:text-luxury-700"
                   />
                 </div>
+                <div className="grid grid-cols-2 gap-4">
+                  <div className="space-y-2">
+                    <Label htmlFor="link-publish-at" className="text-white">
+                      Tayang mulai
+                    </Label>
+                    <Input
+                      id="link-publish-at"
+                      type="datetime-local"
+                      value={toLocalInput(newLink.publish_at)}
+                      onChange={(e) =>
+                        setNewLink({
+                          ...newLink,
+                          publish_at: fromLocalInput(e.target.value),
+                        })
+                      }
+                      className="bg-luxury-100 border-gold/20 text-white"
+                    />
+                  </div>
+                  <div className="space-y-2">
+                    <Label htmlFor="link-unpublish-at" className="text-white">
+                      Tayang sampai
+                    </Label>
+                    <Input
+                      id="link-unpublish-at"
+                      type="datetime-local"
+                      value={toLocalInput(newLink.unpublish_at)}
+                      onChange={(e) =>
+                        setNewLink({
+                          ...newLink,
+                          unpublish_at: fromLocalInput(e.target.value),
+                        })
+                      }
+                      className="bg-luxury-100 border-gold/20 text-white"
+                    />
+                  </div>
+                </div>
                 <div className="flex items-center space-x-2">
                   <Switch
                     id="link-active"
@@ -536,6 +549,8 @@ const LinkManager: React.FC = () => {
                       price: 0,
                       price_str: "",
                       is_active: true,
+                      publish_at: null,
+                      unpublish_at: null,
                     });
                     setValidationErrors({});
                   }}
@@ -627,6 +642,15 @@ const LinkManager: React.FC = () => {
                                 }>
                                 {link.is_active ? "Aktif" : "Tidak Aktif"}
                               </Badge>
+                              {(link.publish_at || link.unpublish_at) &&
+                                link.status &&
+                                scheduleBadges[link.status] && (
+                                  <Badge
+                                    variant="outline"
+                                    className={scheduleBadges[link.status].className}>
+                                    {scheduleBadges[link.status].label}
+                                  </Badge>
+                                )}
                             </div>
                           </div>
                         </div>
@@ -779,6 +803,54 @@ const LinkManager: React.FC = () => {
                                       className="bg-luxury-100 border-gold/20 text-white placeholder:text-luxury-700"
                                     />
                                   </div>
+                                  <div className="grid grid-cols-2 gap-4">
+                                    <div className="space-y-2">
+                                      <Label
+                                        htmlFor="edit-publish-at"
+                                        className="text-white">
+                                        Tayang mulai
+                                      </Label>
+                                      <Input
+                                        id="edit-publish-at"
+                                        type="datetime-local"
+                                        value={toLocalInput(editingLink?.publish_at)}
+                                        onChange={(e) =>
+                                          setEditingLink((prev) =>
+                                            prev
+                                              ? {
+                                                  ...prev,
+                                                  publish_at: fromLocalInput(e.target.value),
+                                                }
+                                              : null
+                                          )
+                                        }
+                                        className="bg-luxury-100 border-gold/20 text-white"
+                                      />
+                                    </div>
+                                    <div className="space-y-2">
+                                      <Label
+                                        htmlFor="edit-unpublish-at"
+                                        className="text-white">
+                                        Tayang sampai
+                                      </Label>
+                                      <Input
+                                        id="edit-unpublish-at"
+                                        type="datetime-local"
+                                        value={toLocalInput(editingLink?.unpublish_at)}
+                                        onChange={(e) =>
+                                          setEditingLink((prev) =>
+                                            prev
+                                              ? {
+                                                  ...prev,
+                                                  unpublish_at: fromLocalInput(e.target.value),
+                                                }
+                                              : null
+                                          )
+                                        }
+                                        className="bg-luxury-100 border-gold/20 text-white"
+                                      />
+                                    </div>
+                                  </div>
                                   <div className="flex items-center space-x-2">
                                     <Switch
                                       id="edit-active"
@@ -18,6 +18,7 @@ import {
   AlertTitle,
 } from "@/components/ui/alert";
 import { useNavigate } from "react-router-dom";
+import AuthService from "@/services/authService";
 
 interface PasswordRequirement {
   text: string;
@@ -43,7 +44,7 @@ const ChangePw: React.FC = () => {
 
   // Password requirements
   const [requirements, setRequirements] = useState<PasswordRequirement[]>([
-    { text: "Minimal 8 karakter", met: false },
+    { text: "Minimal 12 karakter", met: false },
     { text: "Minimal 1 huruf besar", met: false },
     { text: "Minimal 1 huruf kecil", met: false },
     { text: "Minimal 1 angka", met: false },
@@ -66,7 +67,7 @@ const ChangePw: React.FC = () => {
   useEffect(() => {
     const checkRequirements = () => {
       const newRequirements = [
-        { text: "Minimal 8 karakter", met: newPassword.length >= 8 },
+        { text: "Minimal 12 karakter", met: newPassword.length >= 12 },
         { text: "Minimal 1 huruf besar", met: /[A-Z]/.test(newPassword) },
         { text: "Minimal 1 huruf kecil", met: /[a-z]/.test(newPassword) },
         { text: "Minimal 1 angka", met: /[0-9]/.test(newPassword) },        
@@ -108,20 +109,11 @@ const ChangePw: React.FC = () => {
     setIsLoading(true);
     
     try {
-      // Get token from storage
-      const token = localStorage.getItem("authToken") || 
-                    sessionStorage.getItem("authToken");
-
-      if (!token) {
-        throw new Error("Tidak ada token autentikasi");
-      }
-
       // API call to change password
-      const response = await fetch("https://api.sekawan-grup.com/api/change-password", {
-        method: "POST",
+      const response = await AuthService.fetch("https://api.sekawan-grup.com/api/change-password", {
+        method: "PATCH",
         headers: {
           "Content-Type": "application/json",
-          "Authorization": `Bearer ${token}`,
         },
         body: JSON.stringify({
           current_password: currentPassword,
@@ -42,10 +42,11 @@ const Login: React.FC = () => {
 
     try {
       // Gunakan AuthService untuk login
-      await AuthService.login(username, password, rememberMe);
+      const mustChangePassword = await AuthService.login(username, password, rememberMe);
 
-      // Navigate ke dashboard setelah login berhasil
-      navigate("/admin/dashboard");
+      // Navigate ke dashboard setelah login berhasil, atau ke halaman
+      // ubah password bila password awal harus diganti
+      navigate(mustChangePassword ? "/admin/change-password" : "/admin/dashboard");
     } catch (err) {
       // Tangani error login
       setError(
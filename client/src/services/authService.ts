@@ -13,7 +13,19 @@ interface TokenPayload {
   username: string;
 }
 
+const API_URL = 'https://api.sekawan-grup.com/api';
+
+// Refresh the access token this long before it expires
+const REFRESH_MARGIN_MS = 60 * 1000;
+
+// Page where admins change a password they were told to change
+const CHANGE_PASSWORD_PATH = '/admin/change-password';
+
 class AuthService {
+  // Refresh request in flight, shared so parallel requests don't reuse
+  // the same refresh token (the server revokes the session when they do)
+  private static refreshing: Promise<string | null> | null = null;
+
   // Method to get token from storage
   static getToken(): string | null {
     return localStorage.getItem('authToken') || 
@@ -26,6 +38,135 @@ class AuthService {
     return this.getToken();
   }
 
+  // Method to get refresh token from storage
+  static getRefreshToken(): string | null {
+    return localStorage.getItem('refreshToken') ||
+           sessionStorage.getItem('refreshToken') ||
+           null;
+  }
+
+  // Store a token pair in the storage that already holds the session
+  private static storeTokens(token: string, refreshToken: string, rememberMe: boolean): void {
+    const storage = rememberMe ? localStorage : sessionStorage;
+    storage.setItem('authToken', token);
+    storage.setItem('refreshToken', refreshToken);
+  }
+
+  // Check if the access token expires within the refresh margin
+  private static isTokenExpiring(token: string): boolean {
+    try {
+      const decoded = jwtDecode<TokenPayload>(token);
+      return decoded.exp * 1000 - REFRESH_MARGIN_MS < Date.now();
+    } catch {
+      return true;
+    }
+  }
+
+  // Exchange the refresh token for a new token pair
+  static refreshToken(): Promise<string | null> {
+    if (this.refreshing) return this.refreshing;
+
+    this.refreshing = (async () => {
+      const refreshToken = this.getRefreshToken();
+      if (!refreshToken) return null;
+
+      try {
+        const response = await fetch(`${API_URL}/token/refresh`, {
+          method: 'POST',
+          headers: {
+            'Content-Type': 'application/json',
+            'Accept': 'application/json',
+          },
+          body: JSON.stringify({ refresh_token: refreshToken })
+        });
+        if (!response.ok) return null;
+
+        const data = await response.json();
+        const rememberMe = localStorage.getItem('refreshToken') !== null;
+        this.storeTokens(data.token, data.refresh_token, rememberMe);
+        return data.token as string;
+      } catch (error) {
+        console.error('Token refresh error:', error);
+        return null;
+      }
+    })().finally(() => {
+      this.refreshing = null;
+    });
+
+    return this.refreshing;
+  }
+
+  // Fetch an admin endpoint, refreshing the access token before it expires
+  // and once more if the server rejects it. Sends the user back to the
+  // login page when the session can't be refreshed.
+  static async fetch(url: string, init: RequestInit = {}): Promise<Response> {
+    let token = this.getTokenFromStorage();
+    if (!token || this.isTokenExpiring(token)) {
+      token = (await this.refreshToken()) || token;
+    }
+    if (!token) {
+      this.clearTokens();
+      this.redirectToLogin();
+      throw new Error('Sesi telah berakhir, silakan login kembali');
+    }
+
+    const request = (accessToken: string) => {
+      const headers = new Headers(init.headers);
+      headers.set('Authorization', `Bearer ${accessToken}`);
+      return fetch(url, { ...init, headers });
+    };
+
+    let response = await request(token);
+    if (response.status === 401) {
+      const refreshed = await this.refreshToken();
+      if (!refreshed) {
+        this.clearTokens();
+        this.redirectToLogin();
+        throw new Error('Sesi telah berakhir, silakan login kembali');
+      }
+      response = await request(refreshed);
+    }
+
+    // The server refuses everything but the password change until the
+    // admin has replaced their initial password
+    if (response.status === 403) {
+      const data = await response.clone().json().catch(() => null);
+      if (data?.must_change_password) {
+        this.redirectToChangePassword();
+      }
+    }
+    return response;
+  }
+
+  // Send the admin to the change password page unless already there
+  static redirectToChangePassword(): void {
+    if (window.location.pathname !== CHANGE_PASSWORD_PATH) {
+      window.location.href = CHANGE_PASSWORD_PATH;
+    }
+  }
+
+  // Fetch every page of a paginated admin list by following its next links
+  static async fetchAll<T>(url: string, errorMessage: string): Promise<T[]> {
+    const items: T[] = [];
+    let next: string | null = url;
+    while (next) {
+      const response = await this.fetch(new URL(next, API_URL).toString(), {
+        method: 'GET',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+      });
+      if (!response.ok) {
+        throw new Error(errorMessage);
+      }
+
+      const page = await response.json();
+      items.push(...page.data);
+      next = page.next;
+    }
+    return items;
+  }
+
   // Check if token is valid
   static isTokenValid(): boolean {
     const token = this.getTokenFromStorage();
@@ -33,6 +174,9 @@ class AuthService {
     // No token means not authenticated
     if (!token) return false;
 
+    // An expired access token is refreshed on the next request
+    if (this.getRefreshToken()) return true;
+
     try {
       // Decode the token
       const decoded = jwtDecode<TokenPayload>(token);
@@ -49,10 +193,11 @@ class AuthService {
     }
   }
 
-  // Login method
-  static async login(username: string, password: string, rememberMe: boolean): Promise<string> {
+  // Login method. Resolves to whether the admin must change their password
+  // before using the dashboard.
+  static async login(username: string, password: string, rememberMe: boolean): Promise<boolean> {
     try {
-      const response = await fetch('https://api.sekawan-grup.com/api/login', {
+      const response = await fetch(`${API_URL}/login`, {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -73,13 +218,9 @@ class AuthService {
       const token = data.token;
       
       // Store in appropriate storage based on remember me
-      if (rememberMe) {
-        localStorage.setItem('authToken', token);
-      } else {
-        sessionStorage.setItem('authToken', token);
-      }
+      this.storeTokens(token, data.refresh_token, rememberMe);
 
-      return token;
+      return data.must_change_password === true;
     } catch (error) {
       console.error('Login error:', error);
       throw error;
@@ -99,7 +240,7 @@ class AuthService {
       }
 
       // Attempt to call logout endpoint
-      const response = await fetch('https://api.sekawan-grup.com/api/logout', {
+      const response = await fetch(`${API_URL}/logout`, {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -130,6 +271,8 @@ class AuthService {
   static clearTokens(): void {
     localStorage.removeItem('authToken');
     sessionStorage.removeItem('authToken');
+    localStorage.removeItem('refreshToken');
+    sessionStorage.removeItem('refreshToken');
   }
 
   // Redirect to login page
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"raya/models"
+	"raya/utils"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -26,9 +29,299 @@ func InitDB() (*gorm.DB, error) {
 
 	err = db.AutoMigrate(
 		&models.Admin{},
+		&models.RecoveryCode{},
 		&models.Category{},
 		&models.Link{},
+		&models.RevokedToken{},
+		&models.RefreshToken{},
+		&models.Session{},
+		&models.APIKey{},
+		&models.AuditLog{},
+		&models.Revision{},
+		&models.GoldQuote{},
+		&models.PriceChange{},
+		&models.ExchangeRate{},
+		&models.SchemaMigration{},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := protectAuditLog(db); err != nil {
+		return nil, err
+	}
+
+	if err := enforceUniqueOrder(db); err != nil {
+		return nil, err
+	}
+
+	// PriceStr was free text before it was rendered from Price.
+	if err := RunMigration(db, "price_str", migratePriceStrings); err != nil {
+		return nil, err
+	}
+
+	// Links priced before the price history existed start it with their
+	// current price, known to hold since their last update.
+	err = db.Exec(`INSERT INTO price_changes (link_id, price, changed_at)
+		SELECT id, price, updated_at FROM links
+		WHERE NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.link_id = links.id)`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// SKUs are optional but must not repeat among live links.
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_links_sku ON links (sku) WHERE sku <> '' AND deleted_at IS NULL`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = setupSearch(db)
 
 	return db, err
+}
+
+// RunMigration applies a one-time data migration. It is marked done in the
+// same transaction, so a migration that fails is tried again on the next
+// start.
+func RunMigration(db *gorm.DB, name string, migrate func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var done int64
+		if err := tx.Model(&models.SchemaMigration{}).Where("name = ?", name).Count(&done).Error; err != nil {
+			return err
+		}
+		if done > 0 {
+			return nil
+		}
+
+		if err := migrate(tx); err != nil {
+			return err
+		}
+		return tx.Create(&models.SchemaMigration{Name: name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// migratePriceStrings reads the free-text PriceStr of every link once. A
+// label that is just an amount is what customers saw, so it becomes Price and
+// is rendered again. Other labels, and amounts that are not positive or far
+// from Price, are kept as overrides.
+func migratePriceStrings(tx *gorm.DB) error {
+	var links []models.Link
+	err := tx.Unscoped().Select("id", "price", "price_str").
+		Where("price_str_override = ?", false).Find(&links).Error
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, link := range links {
+		updates := map[string]interface{}{}
+		price := link.Price
+
+		parsed, err := utils.ParseRupiah(link.PriceStr)
+		switch {
+		case err == nil && parsed.Plain && plausiblePrice(parsed.Amount, link.Price):
+			price = parsed.Amount
+			updates["price"] = parsed.Amount
+			updates["price_from"] = parsed.From
+			if parsed.From {
+				updates["price_str"] = utils.FormatRupiahFrom(parsed.Amount)
+			} else {
+				updates["price_str"] = utils.FormatRupiah(parsed.Amount)
+			}
+		case strings.TrimSpace(link.PriceStr) == "":
+			updates["price_str"] = utils.FormatRupiah(link.Price)
+		default:
+			updates["price_str_override"] = true
+		}
+
+		if err := tx.Model(&models.Link{}).Unscoped().Where("id = ?", link.ID).UpdateColumns(updates).Error; err != nil {
+			return err
+		}
+		if price != link.Price {
+			change := models.PriceChange{LinkID: link.ID, Price: price, ChangedAt: now}
+			if err := tx.Create(&change).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// plausiblePrice reports whether an amount read from a label can replace
+// price: it must be positive and, when price is set, within a factor of two
+// of it.
+func plausiblePrice(amount, price int64) bool {
+	if amount <= 0 {
+		return false
+	}
+	return price <= 0 || amount*2 >= price && amount <= price*2
+}
+
+// setupSearch prepares the public product search: the sekawan_search text
+// search configuration strips accents and, when the server ships the
+// Snowball Indonesian stemmer, reduces words to their stem. pg_trgm backs the
+// fallback for misspelled queries.
+//
+// Both searches also match the category name, so triggers keep a weighted
+// title and category document (search_document) and the same text folded
+// to lower case without accents (search_text) on every link, and the indexes
+// are built on those.
+func setupSearch(db *gorm.DB) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS unaccent`,
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE OR REPLACE FUNCTION sekawan_fold(text) RETURNS text
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+			AS $$ SELECT lower(public.unaccent('public.unaccent'::regdictionary, $1)) $$`,
+		// The mapping is checked on every start so a stemmer installed
+		// later is picked up, and the stored documents are rebuilt with it.
+		`DO $$
+		DECLARE
+			stemmer boolean := EXISTS (SELECT 1 FROM pg_ts_dict WHERE dictname = 'indonesian_stem');
+			mapped boolean;
+			stemmed boolean;
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'sekawan_search') THEN
+				CREATE TEXT SEARCH CONFIGURATION sekawan_search (COPY = simple);
+			END IF;
+
+			SELECT bool_or(d.dictname = 'unaccent'), bool_or(d.dictname = 'indonesian_stem')
+				INTO mapped, stemmed
+				FROM pg_ts_config_map m
+				JOIN pg_ts_config c ON c.oid = m.mapcfg
+				JOIN pg_ts_dict d ON d.oid = m.mapdict
+				WHERE c.cfgname = 'sekawan_search';
+			IF coalesce(mapped, false) AND coalesce(stemmed, false) = stemmer THEN
+				RETURN;
+			END IF;
+
+			IF stemmer THEN
+				ALTER TEXT SEARCH CONFIGURATION sekawan_search
+					ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part
+					WITH unaccent, indonesian_stem;
+			ELSE
+				ALTER TEXT SEARCH CONFIGURATION sekawan_search
+					ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part
+					WITH unaccent, simple;
+			END IF;
+
+			IF EXISTS (SELECT 1 FROM information_schema.columns
+				WHERE table_name = 'links' AND column_name = 'search_document') THEN
+				UPDATE links SET title = title;
+			END IF;
+		END
+		$$`,
+		`ALTER TABLE links ADD COLUMN IF NOT EXISTS search_document tsvector`,
+		`ALTER TABLE links ADD COLUMN IF NOT EXISTS search_text text`,
+		`CREATE OR REPLACE FUNCTION links_search_refresh() RETURNS trigger
+			LANGUAGE plpgsql AS $$
+		DECLARE
+			category_name text;
+		BEGIN
+			SELECT name INTO category_name FROM categories WHERE id = NEW.category_id;
+			NEW.search_document := setweight(to_tsvector('sekawan_search', coalesce(NEW.title, '')), 'A') ||
+				setweight(to_tsvector('sekawan_search', coalesce(category_name, '')), 'B');
+			NEW.search_text := sekawan_fold(coalesce(NEW.title, '') || ' ' || coalesce(category_name, ''));
+			RETURN NEW;
+		END
+		$$`,
+		`DROP TRIGGER IF EXISTS links_search_refresh ON links`,
+		`CREATE TRIGGER links_search_refresh
+			BEFORE INSERT OR UPDATE OF title, category_id ON links
+			FOR EACH ROW EXECUTE FUNCTION links_search_refresh()`,
+		`CREATE OR REPLACE FUNCTION categories_search_refresh() RETURNS trigger
+			LANGUAGE plpgsql AS $$
+		BEGIN
+			UPDATE links SET title = title WHERE category_id = NEW.id;
+			RETURN NULL;
+		END
+		$$`,
+		`DROP TRIGGER IF EXISTS categories_search_refresh ON categories`,
+		`CREATE TRIGGER categories_search_refresh
+			AFTER UPDATE OF name ON categories
+			FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+			EXECUTE FUNCTION categories_search_refresh()`,
+		`UPDATE links SET title = title WHERE search_document IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_links_search_document ON links USING gin (search_document)`,
+		`CREATE INDEX IF NOT EXISTS idx_links_search_text ON links USING gin (search_text gin_trgm_ops)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforceUniqueOrder adds unique indexes so no two categories, and no two
+// links in one category, share a position. Trashed rows are left out of the
+// indexes and get a fresh position when restored. Existing duplicates are
+// renumbered once, before the indexes are created.
+func enforceUniqueOrder(db *gorm.DB) error {
+	indexes := []struct {
+		model     interface{}
+		name      string
+		renumber  string
+		createSQL string
+	}{
+		{
+			model: &models.Category{},
+			name:  "idx_categories_order",
+			renumber: `UPDATE categories SET "order" = ranked.position
+				FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY "order", id) AS position
+					FROM categories WHERE deleted_at IS NULL) ranked
+				WHERE categories.id = ranked.id`,
+			createSQL: `CREATE UNIQUE INDEX idx_categories_order ON categories ("order") WHERE deleted_at IS NULL`,
+		},
+		{
+			model: &models.Link{},
+			name:  "idx_links_category_order",
+			renumber: `UPDATE links SET "order" = ranked.position
+				FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY category_id ORDER BY "order", id) AS position
+					FROM links WHERE deleted_at IS NULL) ranked
+				WHERE links.id = ranked.id`,
+			createSQL: `CREATE UNIQUE INDEX idx_links_category_order ON links (category_id, "order") WHERE deleted_at IS NULL`,
+		},
+	}
+
+	for _, index := range indexes {
+		if db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(index.renumber).Error; err != nil {
+				return err
+			}
+			return tx.Exec(index.createSQL).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protectAuditLog installs a trigger that rejects UPDATE and DELETE on
+// audit_logs, so the table stays append-only even for direct SQL access.
+func protectAuditLog(db *gorm.DB) error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs`,
+		`CREATE TRIGGER audit_logs_append_only
+			BEFORE UPDATE OR DELETE ON audit_logs
+			FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only()`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
\ No newline at end of file
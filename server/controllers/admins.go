@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/models"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetAdmins godoc
+// @Summary Get all admins
+// @Description Get a list of all admin accounts
+// @Tags admins
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.Admin
+// @Failure 403 {object} map[string]string "message: Forbidden"
+// @Failure 500 {object} map[string]string "message: Error fetching admins"
+// @Router /api/admins [get]
+func GetAdmins(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	admins, err := services.GetAdmins(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching admins"})
+		return
+	}
+
+	c.JSON(http.StatusOK, admins)
+}
+
+// GetAdminByID godoc
+// @Summary Get an admin by ID
+// @Description Get details of a specific admin account
+// @Tags admins
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Admin ID"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Admin not found"
+// @Router /api/admins/{id} [get]
+func GetAdminByID(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	admin, err := services.GetAdminByID(db, uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Admin not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// CreateAdmin godoc
+// @Summary Create a new admin
+// @Description Create an admin account with the given role (owner, editor or viewer)
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param admin body object true "Admin Data"
+// @Success 201 {object} models.Admin
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Router /api/admins [post]
+func CreateAdmin(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required,min=6"`
+		Role     string `json:"role" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	admin, err := services.CreateAdmin(db, input.Username, input.Password, input.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, admin)
+}
+
+// UpdateAdmin godoc
+// @Summary Update an admin
+// @Description Change an admin's role or reset their password
+// @Tags admins
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Admin ID"
+// @Param admin body object true "Admin Data"
+// @Success 200 {object} models.Admin
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Failure 404 {object} map[string]string "message: Admin not found"
+// @Router /api/admins/{id} [patch]
+func UpdateAdmin(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	var input struct {
+		Role     string `json:"role"`
+		Password string `json:"password"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	admin, err := services.UpdateAdmin(db, uint(id), input.Role, input.Password)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Admin not found"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// DeleteAdmin godoc
+// @Summary Delete an admin
+// @Description Delete an admin account and revoke its sessions
+// @Tags admins
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Admin ID"
+// @Success 200 {object} map[string]string "message: Admin deleted successfully"
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Admin not found"
+// @Router /api/admins/{id} [delete]
+func DeleteAdmin(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	if err := services.DeleteAdmin(db, uint(id), user.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Admin not found"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Admin deleted successfully"})
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/models"
+	"raya/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetAPIKeys godoc
+// @Summary Get all API keys
+// @Description List API keys without their secret values
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.APIKey
+// @Failure 500 {object} map[string]string "message: Error fetching API keys"
+// @Router /api/api-keys [get]
+func GetAPIKeys(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	keys, err := services.GetAPIKeys(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create a scoped API key. The key is only returned in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key body object true "Name, scopes and optional expires_at"
+// @Success 201 {object} services.CreatedAPIKey
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Router /api/api-keys [post]
+func CreateAPIKey(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+
+	var input struct {
+		Name      string     `json:"name" binding:"required"`
+		Scopes    []string   `json:"scopes" binding:"required"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	key, err := services.CreateAPIKey(db, user, input.Name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key so it can no longer be used
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "API Key ID"
+// @Success 200 {object} map[string]string "message: API key revoked"
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: API key not found"
+// @Router /api/api-keys/{id} [delete]
+func RevokeAPIKey(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	if err := services.RevokeAPIKey(db, uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "API key not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error revoking API key"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"net/http"
+	"raya/models"
+	"raya/repositories"
+	"raya/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// actorFromContext describes the authenticated caller for audit entries.
+func actorFromContext(c *gin.Context) services.Actor {
+	actor := services.Actor{
+		IP:        c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	}
+
+	if user, ok := c.Get("user"); ok {
+		admin := user.(*models.Admin)
+		actor.AdminID = admin.ID
+		actor.Username = admin.Username
+	}
+	if key, ok := c.Get("api_key"); ok {
+		id := key.(*models.APIKey).ID
+		actor.APIKeyID = &id
+	}
+
+	return actor
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit log
+// @Description Get catalog mutations, newest first, filtered and paginated
+// @Tags audit
+// @Produce json
+// @Security ApiKeyAuth
+// @Param actor_id query int false "Admin ID"
+// @Param action query string false "create, update or delete"
+// @Param entity_type query string false "link or category"
+// @Param entity_id query int false "Entity ID"
+// @Param from query string false "Start time (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "End time, exclusive (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page (max 100)"
+// @Success 200 {object} services.AuditPage
+// @Failure 400 {object} map[string]string "message: Invalid filter"
+// @Failure 500 {object} map[string]string "message: Error fetching audit log"
+// @Router /api/audit [get]
+func GetAuditLogs(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	filter := repositories.AuditFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+	}
+
+	var err error
+	if filter.ActorID, err = parseOptionalID(c.Query("actor_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid actor_id"})
+		return
+	}
+	if filter.EntityID, err = parseOptionalID(c.Query("entity_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid entity_id"})
+		return
+	}
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from"})
+		return
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to"})
+		return
+	}
+
+	page, perPage := pageParams(c)
+
+	logs, err := services.GetAuditLogs(db, filter, page, perPage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching audit log"})
+		return
+	}
+
+	setPageLinks(c, logs)
+	c.JSON(http.StatusOK, logs)
+}
+
+func parseOptionalID(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	return uint(id), err
+}
+
+// parseOptionalTime accepts RFC3339 timestamps or plain dates.
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
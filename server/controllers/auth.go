@@ -1,23 +1,27 @@
 package controllers
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"raya/services"
 	"raya/models"
+	"strconv"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // LoginUser godoc
 // @Summary Login user
-// @Description Authenticate user and return a token
+// @Description Authenticate user and return a short-lived access token and a refresh token, or a challenge token when 2FA is enabled
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param login body object true "Login Credentials" 
-// @Success 200 {object} map[string]string "token: JWT Token"
+// @Success 200 {object} services.LoginResult
 // @Failure 400 {object} map[string]string "message: Username and password are required"
 // @Failure 401 {object} map[string]string "message: Invalid credentials"
+// @Failure 429 {object} map[string]string "message: Too many login attempts"
 // @Router /api/login [post]
 func LoginUser(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
@@ -32,15 +36,65 @@ func LoginUser(c *gin.Context) {
 		return
 	}
 
-	token, err := services.LoginUser(db, input.Username, input.Password)
+	result, err := services.LoginUser(db, input.Username, input.Password, clientFromContext(c))
 	if err != nil {
+		if respondTooManyAttempts(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid credentials"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-	})
+	c.JSON(http.StatusOK, result)
+}
+
+func clientFromContext(c *gin.Context) services.Client {
+	return services.Client{IP: c.ClientIP(), UserAgent: c.GetHeader("User-Agent")}
+}
+
+// respondTooManyAttempts writes a 429 with Retry-After if err is a login
+// throttling error and reports whether it did.
+func respondTooManyAttempts(c *gin.Context, err error) bool {
+	var throttled *services.TooManyAttemptsError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"message": "Too many login attempts"})
+	return true
+}
+
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a rotated refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body object true "Refresh Token"
+// @Success 200 {object} services.TokenPair
+// @Failure 400 {object} map[string]string "message: Refresh token is required"
+// @Failure 401 {object} map[string]string "message: Invalid refresh token"
+// @Router /api/token/refresh [post]
+func RefreshToken(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Refresh token is required"})
+		return
+	}
+
+	tokens, err := services.RefreshTokens(db, input.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
 }
 
 // ChangePassword godoc
@@ -61,7 +115,7 @@ func ChangePassword(c *gin.Context) {
 
 	var input struct {
 		CurrentPassword string `json:"current_password" binding:"required"`
-		NewPassword     string `json:"new_password" binding:"required,min=6"`
+		NewPassword     string `json:"new_password" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -84,7 +138,38 @@ func ChangePassword(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} map[string]string "message: Logout successful"
+// @Failure 500 {object} map[string]string "message: Error logging out"
 // @Router /api/logout [post]
 func LogoutUser(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+	jti := c.GetString("token_id")
+	sessionID := c.GetString("session_id")
+	expiresAt := c.GetTime("token_expires_at")
+
+	if err := services.LogoutUser(db, jti, sessionID, user.ID, expiresAt); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error logging out"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
+}
+
+// GetJWKS godoc
+// @Summary Get JSON Web Key Set
+// @Description Public keys that verify admin access tokens, for other Sekawan services
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.JWKSet
+// @Failure 500 {object} map[string]string "message: Error loading keys"
+// @Router /.well-known/jwks.json [get]
+func GetJWKS(c *gin.Context) {
+	jwks, err := services.GetJWKS()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error loading keys"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
 }
\ No newline at end of file
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetExchangeRates godoc
+// @Summary Get current exchange rates
+// @Description Get the latest rupiah price of one unit of every currency prices can be shown in
+// @Tags exchange-rates
+// @Produce json
+// @Success 200 {array} models.ExchangeRate
+// @Failure 500 {object} map[string]string "message: Error fetching exchange rates"
+// @Router /api/exchange-rates [get]
+func GetExchangeRates(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	rates, err := services.GetLatestExchangeRates(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching exchange rates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// RecordExchangeRates godoc
+// @Summary Enter exchange rates
+// @Description Record exchange rates entered by hand. Rates that are not newer than the stored rate of their currency are skipped.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param rates body services.RateFeed true "Rupiah price of one unit by currency"
+// @Success 201 {object} services.RateUpdate
+// @Failure 400 {object} map[string]string "message: Kurs tidak valid"
+// @Failure 500 {object} map[string]string "message: Error recording exchange rates"
+// @Router /api/exchange-rates [post]
+func RecordExchangeRates(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input services.RateFeed
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Format input tidak valid"})
+		return
+	}
+
+	update, err := services.RecordExchangeRates(db, &services.ManualRateSource{Feed: input})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRate) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error recording exchange rates"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, update)
+}
+
+// respondCurrencyError answers requests for a currency that cannot be shown
+// and reports whether it did.
+func respondCurrencyError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrUnknownCurrency):
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	case errors.Is(err, services.ErrNoExchangeRate):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
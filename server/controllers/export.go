@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"raya/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxRestoreSize limits the export document accepted by RestoreCatalog.
+const maxRestoreSize = 50 << 20
+
+// ExportCatalog godoc
+// @Summary Export the catalog
+// @Description Stream all categories and links as a versioned JSON document, or one entity as CSV with format=csv
+// @Tags backup
+// @Produce json,plain
+// @Security ApiKeyAuth
+// @Param format query string false "json (default) or csv"
+// @Param entity query string false "links (default) or categories, for format=csv"
+// @Success 200 {object} services.ExportDocument
+// @Failure 400 {object} map[string]string "message: Format export tidak dikenal"
+// @Router /api/export [get]
+func ExportCatalog(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	date := time.Now().Format("20060102")
+
+	var export func() error
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.Header("Content-Type", "application/json")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="sekawan-export-%s.json"`, date))
+		export = func() error { return services.ExportCatalog(db, c.Writer) }
+	case "csv":
+		entity := c.DefaultQuery("entity", "links")
+		switch entity {
+		case "links":
+			export = func() error { return services.ExportLinksCSV(db, c.Writer) }
+		case "categories":
+			export = func() error { return services.ExportCategoriesCSV(db, c.Writer) }
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Entity export tidak dikenal"})
+			return
+		}
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="sekawan-%s-%s.csv"`, entity, date))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Format export tidak dikenal"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+	if err := export(); err != nil {
+		// The status is already sent, so the client sees a truncated file.
+		c.Error(err)
+		c.Abort()
+	}
+}
+
+// RestoreCatalog godoc
+// @Summary Restore the catalog
+// @Description Load a document from /api/export into a database that has no categories or links yet
+// @Tags backup
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param document body services.ExportDocument true "Export document"
+// @Success 201 {object} map[string]interface{} "categories, links"
+// @Failure 400 {object} map[string]string "message: Dokumen export tidak valid"
+// @Failure 409 {object} map[string]string "message: Database sudah berisi data"
+// @Router /api/restore [post]
+func RestoreCatalog(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRestoreSize)
+	var document services.ExportDocument
+	if err := c.ShouldBindJSON(&document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Dokumen export tidak valid"})
+		return
+	}
+
+	categories, links, err := services.RestoreCatalog(db, &document, actorFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCatalogNotEmpty):
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		case errors.Is(err, services.ErrExportVersion), errors.Is(err, services.ErrRestoreInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error memulihkan katalog"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"categories": categories, "links": links})
+}
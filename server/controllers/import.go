@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"raya/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxImportSize limits import files to what a product spreadsheet can
+// reasonably reach.
+const maxImportSize = 10 << 20
+
+// ImportLinks godoc
+// @Summary Import links
+// @Description Create or update links by URL from CSV or JSON, sent as the request body or as the "file" field of a multipart form. Categories are matched by name and created when missing. Nothing is saved when a row is invalid or dry_run is set.
+// @Tags links
+// @Accept json,mpfd,plain
+// @Produce json
+// @Security ApiKeyAuth
+// @Param dry_run query bool false "Only report what would change"
+// @Param format query string false "csv or json, when it cannot be told from the content type or file name"
+// @Success 200 {object} services.ImportReport
+// @Failure 400 {object} map[string]string "message: File import tidak valid"
+// @Failure 422 {object} services.ImportReport
+// @Router /api/import [post]
+func ImportLinks(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	source, format, err := importSource(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "File import tidak valid"})
+		return
+	}
+	defer source.Close()
+
+	var rows []services.ImportRow
+	if format == "csv" {
+		rows, err = services.ParseImportCSV(source)
+	} else {
+		rows, err = services.ParseImportJSON(source)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	report, err := services.ImportLinks(db, rows, dryRun, actorFromContext(c))
+	if err != nil {
+		if errors.Is(err, services.ErrImportInvalid) {
+			c.JSON(http.StatusUnprocessableEntity, report)
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error mengimpor link: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// importSource returns the uploaded file and whether it is "csv" or "json".
+func importSource(c *gin.Context) (io.ReadCloser, string, error) {
+	format := strings.ToLower(c.Query("format"))
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		if format == "" && strings.EqualFold(filepath.Ext(header.Filename), ".csv") {
+			format = "csv"
+		}
+		return file, format, nil
+	}
+
+	if format == "" && (c.ContentType() == "text/csv" || c.ContentType() == "text/plain") {
+		format = "csv"
+	}
+	return c.Request.Body, format, nil
+}
@@ -1,8 +1,11 @@
 package controllers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"raya/models"
+	"raya/repositories"
 	"raya/services"
 	"strconv"
 
@@ -39,50 +42,70 @@ func GetCategoryByID(c *gin.Context) {
 	c.JSON(http.StatusOK, category)
 }
 
-// GetCategoryByID godoc
-// @Summary Get a category by ID
-// @Description Get details of a specific category by its ID
+// GetCategories godoc
+// @Summary Get categories
+// @Description Get a page of categories, optionally searched by name and sorted
 // @Tags categories
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id path int true "Category ID"
-// @Success 200 {object} models.Category
-// @Failure 400 {object} map[string]string "message: Invalid ID format"
-// @Failure 404 {object} map[string]string "message: Category not found"
+// @Param q query string false "Search in the name"
+// @Param sort query string false "Comma separated keys (id, name, order), prefix with - for descending"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page (max 100)"
+// @Success 200 {object} services.Page[models.Category]
+// @Failure 400 {object} map[string]string "message: Invalid sort"
+// @Failure 500 {object} map[string]string "message: Error fetching categories"
 // @Router /api/categories [get]
 func GetCategories(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 
-	categories, err := services.GetCategories(db)
+	filter := repositories.CategoryFilter{Query: c.Query("q")}
+	page, perPage := pageParams(c)
+
+	categories, err := services.GetCategories(db, filter, c.Query("sort"), page, perPage)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching categories"})
+		if errors.Is(err, services.ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid sort"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching categories"})
+		}
 		return
 	}
 
+	setPageLinks(c, categories)
 	c.JSON(http.StatusOK, categories)
 }
 
-// GetCategoryByID godoc
-// @Summary Get a category by ID
-// @Description Get details of a specific category by its ID
-// @Tags categories
+// GetLinks godoc
+// @Summary Get links
+// @Description Get a page of links, filtered, searched by title or URL and sorted
+// @Tags links
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id path int true "Category ID"
-// @Success 200 {object} models.Category
-// @Failure 400 {object} map[string]string "message: Invalid ID format"
-// @Failure 404 {object} map[string]string "message: Category not found"
+// @Param category_id query int false "Category ID"
+// @Param is_active query bool false "Active state"
+// @Param price_min query int false "Lowest price"
+// @Param price_max query int false "Highest price"
+// @Param created_from query string false "Created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param created_to query string false "Created before (RFC3339 or YYYY-MM-DD)"
+// @Param updated_from query string false "Updated at or after (RFC3339 or YYYY-MM-DD)"
+// @Param updated_to query string false "Updated before (RFC3339 or YYYY-MM-DD)"
+// @Param product_type query string false "Product type"
+// @Param karat query int false "Karat"
+// @Param brand query string false "Brand, partial match"
+// @Param weight_min query number false "Lowest weight in grams"
+// @Param weight_max query number false "Highest weight in grams"
+// @Param sku query string false "SKU"
+// @Param q query string false "Search in the title and URL"
+// @Param sort query string false "Comma separated keys (id, title, price, order, category_id, created_at, updated_at, weight_grams, karat), prefix with - for descending"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page (max 100)"
+// @Success 200 {object} services.Page[models.Link]
+// @Failure 400 {object} map[string]string "message: Invalid filter"
+// @Failure 500 {object} map[string]string "message: Error fetching links"
 // @Router /api/links [get]
 func GetLinks(c *gin.Context) {
-	db := c.MustGet("db").(*gorm.DB)
-
-	links, err := services.GetLinks(db)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching links"})
-		return
-	}
-
-	c.JSON(http.StatusOK, links)
+	getLinkPage(c, services.GetLinks)
 }
 
 // GetAllCategories godoc
@@ -109,38 +132,78 @@ func GetAllCategories(c *gin.Context) {
 
 // GetAllLinks godoc
 // @Summary Get all links
-// @Description Get a list of all links
+// @Description Get a page of links with their category, by default grouped by category in display order. Accepts the same filters as /api/links.
 // @Tags links
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {array} models.Link
+// @Param category_id query int false "Category ID"
+// @Param is_active query bool false "Active state"
+// @Param q query string false "Search in the title and URL"
+// @Param sort query string false "Sort keys, see /api/links"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page (max 100)"
+// @Success 200 {object} services.Page[models.Link]
+// @Failure 400 {object} map[string]string "message: Invalid filter"
 // @Failure 500 {object} map[string]string "message: Error fetching links"
 // @Router /api/links/all [get]
 func GetAllLinks(c *gin.Context) {
+	getLinkPage(c, services.GetAllLinks)
+}
+
+func getLinkPage(c *gin.Context, list func(*gorm.DB, repositories.LinkFilter, string, int, int) (*services.Page[models.Link], error)) {
 	db := c.MustGet("db").(*gorm.DB)
 
-	links, err := services.GetAllLinks(db)
+	filter, err := linkFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	page, perPage := pageParams(c)
+
+	links, err := list(db, filter, c.Query("sort"), page, perPage)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching links"})
+		if errors.Is(err, services.ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid sort"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching links"})
+		}
 		return
 	}
 
+	setPageLinks(c, links)
 	c.JSON(http.StatusOK, links)
 }
 
 // GetCategoriesWithLinks godoc
 // @Summary Get all categories with their links
-// @Description Get a list of all categories with their associated links
+// @Description Get a list of all categories with their associated links. Links on promo carry their sale price and discount under promo.
 // @Tags categories
 // @Produce json
+// @Param product_type query string false "Filter links by product type"
+// @Param karat query int false "Filter links by karat"
+// @Param brand query string false "Filter links by brand, partial match"
+// @Param weight_min query number false "Minimum weight in grams"
+// @Param weight_max query number false "Maximum weight in grams"
+// @Param currency query string false "Also show prices in this currency (MYR, SGD)"
 // @Success 200 {array} models.Category
+// @Failure 400 {object} map[string]string "message: Invalid filter"
 // @Failure 500 {object} map[string]string "message: Error fetching categories with links"
+// @Failure 503 {object} map[string]string "message: Kurs untuk mata uang ini belum tersedia"
 // @Router /api/categories-with-links [get]
 func GetCategoriesWithLinks(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     
-    categories, err := services.GetCategoriesWithLinks(db)
+    filter, err := goldFilterFromQuery(c)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+        return
+    }
+    
+    categories, err := services.GetCategoriesWithLinks(db, filter, c.Query("currency"))
     if err != nil {
+        if respondCurrencyError(c, err) {
+            return
+        }
         c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching categories with links"})
         return
     }
@@ -230,7 +293,7 @@ func GetLinksByCategory(c *gin.Context) {
 // @Success 201 {object} models.Link
 // @Failure 400 {object} map[string]string "message: Format input tidak valid"
 // @Failure 404 {object} map[string]string "message: Kategori tidak ditemukan"
-// @Failure 500 {object} map[string]string "message: Error menentukan urutan"
+// @Failure 409 {object} map[string]string "message: SKU is already used by another link"
 // @Router /api/categories/{category_id}/links [post]
 func CreateLink(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
@@ -255,24 +318,12 @@ func CreateLink(c *gin.Context) {
 	}
 		
 	link.CategoryID = uint(categoryID)
-	
-
-	if link.Order <= 0 {
-	
-		var maxOrder struct {
-			MaxOrder int
-		}
-		if err := db.Model(&models.Link{}).
-			Where("category_id = ?", categoryID).
-			Select("COALESCE(MAX(\"order\"), 0) as max_order").
-			Scan(&maxOrder).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error menentukan urutan"})
+		
+	if err := services.CreateLink(db, &link, actorFromContext(c)); err != nil {
+		if errors.Is(err, services.ErrSKUTaken) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
 			return
 		}
-		link.Order = maxOrder.MaxOrder + 1
-	}
-		
-	if err := services.CreateLink(db, &link); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
@@ -293,6 +344,7 @@ func CreateLink(c *gin.Context) {
 // @Success 200 {object} models.Link
 // @Failure 400 {object} map[string]string "message: Format input tidak valid"
 // @Failure 404 {object} map[string]string "message: Link tidak ditemukan dalam kategori ini"
+// @Failure 409 {object} map[string]string "message: SKU is already used by another link"
 // @Router /api/categories/{category_id}/links/{link_id} [patch]
 func UpdateLink(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
@@ -325,7 +377,11 @@ func UpdateLink(c *gin.Context) {
 		
 	updatedLink.CategoryID = uint(categoryID)
 		
-	if err := services.UpdateLink(db, uint(linkID), &updatedLink); err != nil {
+	if err := services.UpdateLink(db, uint(linkID), &updatedLink, actorFromContext(c)); err != nil {
+		if errors.Is(err, services.ErrSKUTaken) {
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
@@ -370,7 +426,7 @@ func DeleteLink(c *gin.Context) {
 		return
 	}
 		
-	if err := db.Delete(&link).Error; err != nil {
+	if err := services.DeleteLink(db, link.ID, actorFromContext(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error menghapus link"})
 		return
 	}
@@ -378,6 +434,91 @@ func DeleteLink(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Link berhasil dihapus"})
 }
 
+// MoveLink godoc
+// @Summary Move a link
+// @Description Move a link to another category, or to another position in its own category
+// @Tags links
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Param move body object true "category_id and optional 1-based order"
+// @Success 200 {object} models.Link
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Failure 404 {object} map[string]string "message: Link atau kategori tidak ditemukan"
+// @Router /api/links/{id}/move [post]
+func MoveLink(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	var input struct {
+		CategoryID uint `json:"category_id" binding:"required"`
+		Order      int  `json:"order"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	link, err := services.MoveLink(db, uint(id), input.CategoryID, input.Order, actorFromContext(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Link atau kategori tidak ditemukan"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error memindahkan link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// DuplicateLink godoc
+// @Summary Duplicate a link
+// @Description Copy a link as inactive, into its own category or the given category_id
+// @Tags links
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Param duplicate body object false "Optional category_id for the copy"
+// @Success 201 {object} models.Link
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Failure 404 {object} map[string]string "message: Link atau kategori tidak ditemukan"
+// @Router /api/links/{id}/duplicate [post]
+func DuplicateLink(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	var input struct {
+		CategoryID uint `json:"category_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	link, err := services.DuplicateLink(db, uint(id), input.CategoryID, actorFromContext(c))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Link atau kategori tidak ditemukan"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error menduplikasi link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
 // CreateCategory godoc
 // @Summary Create a new category
 // @Description Create a new category with the input data
@@ -388,7 +529,6 @@ func DeleteLink(c *gin.Context) {
 // @Param category body models.Category true "Category Data"
 // @Success 201 {object} models.Category
 // @Failure 400 {object} map[string]string "message: Invalid input format"
-// @Failure 500 {object} map[string]string "message: Error menentukan urutan"
 // @Router /api/categories [post]
 func CreateCategory(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
@@ -400,20 +540,7 @@ func CreateCategory(c *gin.Context) {
 	}
 
 
-	if category.Order <= 0 {
-		var maxOrder struct {
-			MaxOrder int
-		}
-		if err := db.Model(&models.Category{}).
-			Select("COALESCE(MAX(\"order\"), 0) as max_order").
-			Scan(&maxOrder).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error menentukan urutan"})
-			return
-		}
-		category.Order = maxOrder.MaxOrder + 1
-	}
-
-	if err := services.CreateCategory(db, &category); err != nil {
+	if err := services.CreateCategory(db, &category, actorFromContext(c)); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
@@ -449,7 +576,7 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
-	if err := services.UpdateCategory(db, uint(id), &category); err != nil {
+	if err := services.UpdateCategory(db, uint(id), &category, actorFromContext(c)); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"message": "Category not found"})
 		} else {
@@ -482,7 +609,7 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := services.DeleteCategory(db, uint(id)); err != nil {
+	if err := services.DeleteCategory(db, uint(id), actorFromContext(c)); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"message": "Category not found"})
 		} else {
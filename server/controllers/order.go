@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type reorderInput struct {
+	IDs []uint `json:"ids" binding:"required"`
+}
+
+// ReorderCategories godoc
+// @Summary Reorder categories
+// @Description Set the position of every category at once from the full ordered list of IDs
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param order body object true "ids: every category ID in the new order"
+// @Success 200 {array} models.Category
+// @Failure 400 {object} map[string]string "message: Invalid input format"
+// @Router /api/categories/order [put]
+func ReorderCategories(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input reorderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid input format"})
+		return
+	}
+
+	categories, err := services.ReorderCategories(db, input.IDs, actorFromContext(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOrder) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error mengubah urutan kategori"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// ReorderLinks godoc
+// @Summary Reorder links in a category
+// @Description Set the position of every link in a category at once from the full ordered list of IDs
+// @Tags links
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param category_id path int true "Category ID"
+// @Param order body object true "ids: every link ID of the category in the new order"
+// @Success 200 {array} models.Link
+// @Failure 400 {object} map[string]string "message: Format input tidak valid"
+// @Failure 404 {object} map[string]string "message: Kategori tidak ditemukan"
+// @Router /api/categories/{category_id}/links/order [put]
+func ReorderLinks(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	categoryID, err := strconv.ParseUint(c.Param("category_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "ID kategori tidak valid"})
+		return
+	}
+
+	var input reorderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Format input tidak valid"})
+		return
+	}
+
+	links, err := services.ReorderLinks(db, uint(categoryID), input.IDs, actorFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "Kategori tidak ditemukan"})
+		case errors.Is(err, services.ErrInvalidOrder):
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error mengubah urutan link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"fmt"
+	"raya/repositories"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageParams reads page and per_page. Invalid values fall back to the
+// defaults in the service.
+func pageParams(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(services.DefaultPerPage)))
+	return page, perPage
+}
+
+// setPageLinks fills in the URLs of the neighbouring pages, keeping the
+// other query parameters of the request.
+func setPageLinks[T any](c *gin.Context, page *services.Page[T]) {
+	link := func(number int) *string {
+		query := c.Request.URL.Query()
+		query.Set("page", strconv.Itoa(number))
+		query.Set("per_page", strconv.Itoa(page.PerPage))
+		url := c.Request.URL.Path + "?" + query.Encode()
+		return &url
+	}
+
+	if page.HasNext() {
+		page.Next = link(page.Page + 1)
+	}
+	if page.Page > 1 {
+		page.Prev = link(page.Page - 1)
+	}
+}
+
+// linkFilterFromQuery reads the filters shared by the admin link lists.
+func linkFilterFromQuery(c *gin.Context) (repositories.LinkFilter, error) {
+	filter := repositories.LinkFilter{Query: c.Query("q"), SKU: c.Query("sku")}
+
+	var err error
+	if filter.GoldFilter, err = goldFilterFromQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.CategoryID, err = parseOptionalID(c.Query("category_id")); err != nil {
+		return filter, fmt.Errorf("Invalid category_id")
+	}
+	if filter.IsActive, err = parseOptionalBool(c.Query("is_active")); err != nil {
+		return filter, fmt.Errorf("Invalid is_active")
+	}
+	if filter.PriceMin, err = parseOptionalInt64(c.Query("price_min")); err != nil {
+		return filter, fmt.Errorf("Invalid price_min")
+	}
+	if filter.PriceMax, err = parseOptionalInt64(c.Query("price_max")); err != nil {
+		return filter, fmt.Errorf("Invalid price_max")
+	}
+	if filter.CreatedFrom, err = parseOptionalTime(c.Query("created_from")); err != nil {
+		return filter, fmt.Errorf("Invalid created_from")
+	}
+	if filter.CreatedTo, err = parseOptionalTime(c.Query("created_to")); err != nil {
+		return filter, fmt.Errorf("Invalid created_to")
+	}
+	if filter.UpdatedFrom, err = parseOptionalTime(c.Query("updated_from")); err != nil {
+		return filter, fmt.Errorf("Invalid updated_from")
+	}
+	if filter.UpdatedTo, err = parseOptionalTime(c.Query("updated_to")); err != nil {
+		return filter, fmt.Errorf("Invalid updated_to")
+	}
+
+	return filter, nil
+}
+
+// goldFilterFromQuery reads the gold attribute filters shared by the admin
+// link lists and the public catalog.
+func goldFilterFromQuery(c *gin.Context) (repositories.GoldFilter, error) {
+	filter := repositories.GoldFilter{ProductType: c.Query("product_type"), Brand: c.Query("brand")}
+
+	var err error
+	if value := c.Query("karat"); value != "" {
+		if filter.Karat, err = strconv.Atoi(value); err != nil {
+			return filter, fmt.Errorf("Invalid karat")
+		}
+	}
+	if filter.WeightMin, err = parseOptionalFloat64(c.Query("weight_min")); err != nil {
+		return filter, fmt.Errorf("Invalid weight_min")
+	}
+	if filter.WeightMax, err = parseOptionalFloat64(c.Query("weight_max")); err != nil {
+		return filter, fmt.Errorf("Invalid weight_max")
+	}
+
+	return filter, nil
+}
+
+func parseOptionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func parseOptionalInt64(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func parseOptionalFloat64(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetLinkPriceHistory godoc
+// @Summary Get the price history of a link
+// @Description Get the price changes of a link on the public catalog, oldest first. With from set, the change that set the price in effect at from comes first.
+// @Tags links
+// @Produce json
+// @Param id path int true "Link ID"
+// @Param from query string false "Changes at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Changes before (RFC3339 or YYYY-MM-DD)"
+// @Success 200 {object} services.PriceHistory
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Link not found"
+// @Failure 500 {object} map[string]string "message: Error fetching price history"
+// @Router /api/links/{id}/price-history [get]
+func GetLinkPriceHistory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	from, err := parseOptionalTime(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from"})
+		return
+	}
+	to, err := parseOptionalTime(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to"})
+		return
+	}
+
+	history, err := services.GetLinkPriceHistory(db, uint(id), from, to)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Link not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching price history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetDailyGoldPrices godoc
+// @Summary Get the daily gold price series
+// @Description Get the low, high and closing sell price and the closing buy price per gram of a karat for each day with quotes
+// @Tags gold-prices
+// @Produce json
+// @Param karat query int false "Karat (default 24)"
+// @Param days query int false "Number of days up to today (default 30, max 366)"
+// @Success 200 {object} services.GoldPriceSeries
+// @Failure 400 {object} map[string]string "message: Invalid karat or days"
+// @Failure 500 {object} map[string]string "message: Error fetching gold price series"
+// @Router /api/gold-prices/daily [get]
+func GetDailyGoldPrices(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	karat, err := strconv.Atoi(c.DefaultQuery("karat", "24"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid karat"})
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(services.DefaultPriceSeriesDays)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid days"})
+		return
+	}
+
+	series, err := services.GetDailyGoldPrices(db, karat, days)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPriceSeries) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching gold price series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
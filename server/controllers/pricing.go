@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/repositories"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetGoldPrices godoc
+// @Summary Get current gold prices
+// @Description Get the latest buy and sell price per gram of every karat
+// @Tags gold-prices
+// @Produce json
+// @Success 200 {array} models.GoldQuote
+// @Failure 500 {object} map[string]string "message: Error fetching gold prices"
+// @Router /api/gold-prices [get]
+func GetGoldPrices(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	quotes, err := services.GetLatestGoldPrices(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching gold prices"})
+		return
+	}
+
+	c.JSON(http.StatusOK, quotes)
+}
+
+// GetGoldPriceHistory godoc
+// @Summary Get gold price history
+// @Description Get stored gold quotes, newest first
+// @Tags gold-prices
+// @Produce json
+// @Security ApiKeyAuth
+// @Param karat query int false "Karat"
+// @Param from query string false "Quoted at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Quoted before (RFC3339 or YYYY-MM-DD)"
+// @Param page query int false "Page number"
+// @Param per_page query int false "Items per page (max 100)"
+// @Success 200 {object} map[string]interface{} "data, total, page, per_page, next, prev"
+// @Failure 400 {object} map[string]string "message: Invalid filter"
+// @Failure 500 {object} map[string]string "message: Error fetching gold price history"
+// @Router /api/gold-prices/history [get]
+func GetGoldPriceHistory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var filter repositories.GoldQuoteFilter
+	var err error
+	if value := c.Query("karat"); value != "" {
+		if filter.Karat, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid karat"})
+			return
+		}
+	}
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from"})
+		return
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to"})
+		return
+	}
+
+	page, perPage := pageParams(c)
+
+	quotes, err := services.GetGoldPriceHistory(db, filter, page, perPage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching gold price history"})
+		return
+	}
+
+	setPageLinks(c, quotes)
+	c.JSON(http.StatusOK, quotes)
+}
+
+// RecordGoldPrices godoc
+// @Summary Enter gold prices
+// @Description Record gold prices entered by hand and reprice the links that follow the gold price. Prices that are not newer than the stored price of their karat are skipped.
+// @Tags gold-prices
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param prices body services.PriceFeed true "Prices per gram by karat"
+// @Success 201 {object} services.PriceUpdate
+// @Failure 400 {object} map[string]string "message: Harga emas tidak valid"
+// @Failure 500 {object} map[string]string "message: Error recording gold prices"
+// @Router /api/gold-prices [post]
+func RecordGoldPrices(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input services.PriceFeed
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Format input tidak valid"})
+		return
+	}
+
+	update, err := services.RecordGoldPrices(db, &services.ManualPriceSource{Feed: input}, actorFromContext(c))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidQuote) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error recording gold prices"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, update)
+}
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/models"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetLinkRevisions godoc
+// @Summary Get link revisions
+// @Description List the numbered revisions of a link, newest first
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Success 200 {array} models.Revision
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Router /api/links/{id}/revisions [get]
+func GetLinkRevisions(c *gin.Context) {
+	getRevisions(c, models.EntityLink)
+}
+
+// GetCategoryRevisions godoc
+// @Summary Get category revisions
+// @Description List the numbered revisions of a category, newest first
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Category ID"
+// @Success 200 {array} models.Revision
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Router /api/category/{id}/revisions [get]
+func GetCategoryRevisions(c *gin.Context) {
+	getRevisions(c, models.EntityCategory)
+}
+
+// DiffLinkRevisions godoc
+// @Summary Diff two link revisions
+// @Description Show the fields that changed between two revisions of a link
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Param from query int true "Revision number"
+// @Param to query int true "Revision number"
+// @Success 200 {object} services.RevisionDiff
+// @Failure 400 {object} map[string]string "message: Invalid revision numbers"
+// @Failure 404 {object} map[string]string "message: Revision not found"
+// @Router /api/links/{id}/revisions/diff [get]
+func DiffLinkRevisions(c *gin.Context) {
+	diffRevisions(c, models.EntityLink)
+}
+
+// DiffCategoryRevisions godoc
+// @Summary Diff two category revisions
+// @Description Show the fields that changed between two revisions of a category
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Category ID"
+// @Param from query int true "Revision number"
+// @Param to query int true "Revision number"
+// @Success 200 {object} services.RevisionDiff
+// @Failure 400 {object} map[string]string "message: Invalid revision numbers"
+// @Failure 404 {object} map[string]string "message: Revision not found"
+// @Router /api/category/{id}/revisions/diff [get]
+func DiffCategoryRevisions(c *gin.Context) {
+	diffRevisions(c, models.EntityCategory)
+}
+
+// RollbackLink godoc
+// @Summary Roll back a link
+// @Description Restore a link's content from a revision; the rollback is recorded as a new revision
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} models.Link
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Revision not found"
+// @Router /api/links/{id}/revisions/{number}/rollback [post]
+func RollbackLink(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, number, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	link, err := services.RollbackLink(db, id, number, actorFromContext(c))
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// RollbackCategory godoc
+// @Summary Roll back a category
+// @Description Restore a category from a revision; the rollback is recorded as a new revision
+// @Tags revisions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Category ID"
+// @Param number path int true "Revision number"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Revision not found"
+// @Router /api/category/{id}/revisions/{number}/rollback [post]
+func RollbackCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, number, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	category, err := services.RollbackCategory(db, id, number, actorFromContext(c))
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func getRevisions(c *gin.Context, entityType string) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	revisions, err := services.GetRevisions(db, entityType, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching revisions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func diffRevisions(c *gin.Context, entityType string) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid revision numbers"})
+		return
+	}
+
+	diff, err := services.DiffRevisions(db, entityType, uint(id), from, to)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+func parseRevisionParams(c *gin.Context) (uint, int, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return 0, 0, false
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid revision number"})
+		return 0, 0, false
+	}
+
+	return uint(id), number, true
+}
+
+func respondRevisionError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Revision not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+}
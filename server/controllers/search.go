@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SearchLinks godoc
+// @Summary Search products
+// @Description Full-text search over the titles and category names of visible links, falling back to trigram similarity for misspelled queries. Snippets highlight matches with <mark> tags.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results (max 50)"
+// @Param currency query string false "Also show prices in this currency (MYR, SGD)"
+// @Success 200 {object} services.SearchResult
+// @Failure 400 {object} map[string]string "message: Kata kunci pencarian wajib diisi"
+// @Failure 500 {object} map[string]string "message: Error mencari produk"
+// @Failure 503 {object} map[string]string "message: Kurs untuk mata uang ini belum tersedia"
+// @Router /api/search [get]
+func SearchLinks(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(services.DefaultSearchLimit)))
+
+	result, err := services.SearchLinks(db, c.Query("q"), limit, c.Query("currency"))
+	if err != nil {
+		if errors.Is(err, services.ErrEmptySearch) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		} else if !respondCurrencyError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error mencari produk"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
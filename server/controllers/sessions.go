@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/models"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetSessions godoc
+// @Summary Get active sessions
+// @Description List the authenticated admin's active sessions, flagging the current one
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.Session
+// @Failure 500 {object} map[string]string "message: Error fetching sessions"
+// @Router /api/sessions [get]
+func GetSessions(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+
+	sessions, err := services.GetSessions(db, user.ID, c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Sign out one of the authenticated admin's sessions
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Session ID"
+// @Success 200 {object} map[string]string "message: Session revoked"
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Session not found"
+// @Router /api/sessions/{id} [delete]
+func RevokeSession(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	if err := services.RevokeSession(db, user.ID, uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Session not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error revoking session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// RevokeAdminSessions godoc
+// @Summary Sign out an admin everywhere
+// @Description Revoke every session of another admin
+// @Tags admins
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Admin ID"
+// @Success 200 {object} map[string]string "message: All sessions revoked"
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Admin not found"
+// @Router /api/admins/{id}/sessions [delete]
+func RevokeAdminSessions(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	if err := services.RevokeAllSessions(db, uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Admin not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error revoking sessions"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All sessions revoked"})
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/models"
+	"raya/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VerifyTwoFactorLogin godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the challenge token from /login and a TOTP or recovery code for a token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login body object true "Challenge token and code"
+// @Success 200 {object} services.LoginResult
+// @Failure 400 {object} map[string]string "message: Challenge token and code are required"
+// @Failure 401 {object} map[string]string "message: Invalid two-factor code"
+// @Failure 429 {object} map[string]string "message: Too many login attempts"
+// @Router /api/login/2fa [post]
+func VerifyTwoFactorLogin(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	var input struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Challenge token and code are required"})
+		return
+	}
+
+	result, err := services.VerifyTwoFactorLogin(db, input.ChallengeToken, input.Code, clientFromContext(c))
+	if err != nil {
+		if respondTooManyAttempts(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid two-factor code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetupTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generate a TOTP secret and provisioning URI for the authenticated admin
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} services.TOTPSetup
+// @Failure 400 {object} map[string]string "message: 2FA sudah aktif"
+// @Router /api/2fa/setup [post]
+func SetupTOTP(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+
+	setup, err := services.SetupTOTP(db, user.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enable 2FA with a code from the authenticator app and return one-time recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param code body object true "TOTP Code"
+// @Success 200 {object} map[string][]string "recovery_codes: Recovery codes"
+// @Failure 400 {object} map[string]string "message: Input tidak valid"
+// @Router /api/2fa/confirm [post]
+func ConfirmTOTP(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Input tidak valid"})
+		return
+	}
+
+	codes, err := services.ConfirmTOTP(db, user.ID, input.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "Kode 2FA tidak valid"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// DisableTOTP godoc
+// @Summary Disable TOTP
+// @Description Turn off 2FA for the authenticated admin after re-entering the password
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param password body object true "Current Password"
+// @Success 200 {object} map[string]string "message: 2FA dinonaktifkan"
+// @Failure 400 {object} map[string]string "message: Input tidak valid"
+// @Router /api/2fa/disable [post]
+func DisableTOTP(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	user := c.MustGet("user").(*models.Admin)
+
+	var input struct {
+		Password string `json:"password" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Input tidak valid"})
+		return
+	}
+
+	if err := services.DisableTOTP(db, user.ID, input.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "2FA dinonaktifkan"})
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"raya/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetTrashedLinks godoc
+// @Summary Get trashed links
+// @Description Get links that were deleted and can still be restored
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.Link
+// @Failure 500 {object} map[string]string "message: Error fetching trash"
+// @Router /api/trash/links [get]
+func GetTrashedLinks(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	links, err := services.GetTrashedLinks(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// GetTrashedCategories godoc
+// @Summary Get trashed categories
+// @Description Get categories that were deleted and can still be restored
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.Category
+// @Failure 500 {object} map[string]string "message: Error fetching trash"
+// @Router /api/trash/categories [get]
+func GetTrashedCategories(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	categories, err := services.GetTrashedCategories(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Error fetching trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// RestoreLink godoc
+// @Summary Restore a link
+// @Description Restore a link from the trash
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Link ID"
+// @Success 200 {object} models.Link
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Link not found in trash"
+// @Failure 409 {object} map[string]string "message: Category is still in trash"
+// @Router /api/trash/links/{id}/restore [post]
+func RestoreLink(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	link, err := services.RestoreLink(db, uint(id), actorFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "Link not found in trash"})
+		case errors.Is(err, services.ErrCategoryTrashed), errors.Is(err, services.ErrSKUTaken):
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error restoring link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// RestoreCategory godoc
+// @Summary Restore a category
+// @Description Restore a category from the trash together with the links deleted with it
+// @Tags trash
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} map[string]string "message: Invalid ID format"
+// @Failure 404 {object} map[string]string "message: Category not found in trash"
+// @Failure 409 {object} map[string]string "message: SKU is already used by another link"
+// @Router /api/trash/categories/{id}/restore [post]
+func RestoreCategory(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ID format"})
+		return
+	}
+
+	category, err := services.RestoreCategory(db, uint(id), actorFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "Category not found in trash"})
+		case errors.Is(err, services.ErrSKUTaken):
+			c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "Error restoring category"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
@@ -1,13 +1,19 @@
 package database
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"raya/models"
 	"raya/utils"
 
 	"gorm.io/gorm"
 )
 
+// legacyAdminPassword is the password the admin was seeded with before the
+// initial password became configurable.
+const legacyAdminPassword = "admin123"
+
 // AdminFactory creates a default admin user if none exists
 func AdminFactory(db *gorm.DB) error {
 	var count int64
@@ -18,21 +24,88 @@ func AdminFactory(db *gorm.DB) error {
 		return nil
 	}
 
-	// Create default admin
-	defaultPassword, err := utils.HashPassword("admin123")
+	// Use the configured initial password, or generate one and show it once
+	password := os.Getenv("DEFAULT_ADMIN_PASSWORD")
+	generated := password == ""
+	if !generated && len(password) < utils.MinPasswordLength {
+		return fmt.Errorf("DEFAULT_ADMIN_PASSWORD must be at least %d characters", utils.MinPasswordLength)
+	}
+	if generated {
+		random, err := utils.GenerateRandomString(8)
+		if err != nil {
+			return err
+		}
+		password = random
+	}
+
+	defaultPassword, err := utils.HashPassword(password)
 	if err != nil {
 		return err
 	}
 
 	admin := models.Admin{
-		Username: "admin",
-		Password: defaultPassword,
+		Username:           "admin",
+		Password:           defaultPassword,
+		Role:               models.RoleOwner,
+		MustChangePassword: true,
 	}
 
 	if err := db.Create(&admin).Error; err != nil {
 		return err
 	}
 
-	fmt.Println("Default admin user created")
+	if generated {
+		fmt.Printf("Default admin user created with password: %s (must be changed on first login)\n", password)
+	} else {
+		fmt.Println("Default admin user created from DEFAULT_ADMIN_PASSWORD (must be changed on first login)")
+	}
+	return nil
+}
+
+// FlagLegacyAdminPassword makes admins still using the old seeded password
+// change it on their next login.
+func FlagLegacyAdminPassword(tx *gorm.DB) error {
+	var admins []models.Admin
+	if err := tx.Where("must_change_password = ?", false).Find(&admins).Error; err != nil {
+		return err
+	}
+
+	for _, admin := range admins {
+		if !utils.CheckPassword(legacyAdminPassword, admin.Password) {
+			continue
+		}
+		if err := tx.Model(&admin).Update("must_change_password", true).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Admin %s still uses the default password and must change it on next login\n", admin.Username)
+	}
+	return nil
+}
+
+// OwnerFactory promotes the oldest admin to owner when no owner exists, so
+// databases created before roles were introduced keep a manageable account.
+func OwnerFactory(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.Admin{}).Where("role = ?", models.RoleOwner).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	var admin models.Admin
+	if err := db.Order("id asc").First(&admin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := db.Model(&admin).Update("role", models.RoleOwner).Error; err != nil {
+		return err
+	}
+
+	fmt.Printf("Admin %s promoted to owner\n", admin.Username)
 	return nil
-}
\ No newline at end of file
+}
@@ -1,7 +1,17 @@
 package database
 
-import "gorm.io/gorm"
+import (
+	"raya/config"
+
+	"gorm.io/gorm"
+)
 
 func SeedDatabase(db *gorm.DB) error {
-	return AdminFactory(db)
+	if err := AdminFactory(db); err != nil {
+		return err
+	}
+	if err := config.RunMigration(db, "flag_legacy_admin_password", FlagLegacyAdminPassword); err != nil {
+		return err
+	}
+	return OwnerFactory(db)
 }
\ No newline at end of file
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/services"
+)
+
+// StartPriceFeed records gold prices from source right away and then at every
+// interval.
+func StartPriceFeed(db *gorm.DB, source services.PriceSource, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			update, err := services.RecordGoldPrices(db, source, services.PriceFeedActor)
+			if err != nil {
+				log.Printf("Error recording gold prices from %s: %v", source.Name(), err)
+			} else if len(update.Quotes) > 0 {
+				log.Printf("Recorded %d gold prices from %s and repriced %d links", len(update.Quotes), update.Source, update.Repriced)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/services"
+)
+
+// StartRateFeed records exchange rates from source right away and then at
+// every interval.
+func StartRateFeed(db *gorm.DB, source services.RateSource, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			update, err := services.RecordExchangeRates(db, source)
+			if err != nil {
+				log.Printf("Error recording exchange rates from %s: %v", source.Name(), err)
+			} else if len(update.Rates) > 0 {
+				log.Printf("Recorded %d exchange rates from %s", len(update.Rates), update.Source)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/services"
+)
+
+// StartPublishScheduler periodically applies the links' publishing windows.
+func StartPublishScheduler(db *gorm.DB, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			published, unpublished, err := services.ApplyPublishingSchedule(db)
+			if err != nil {
+				log.Printf("Error applying publishing schedule: %v", err)
+				continue
+			}
+			if published > 0 || unpublished > 0 {
+				log.Printf("Published %d links and unpublished %d links", published, unpublished)
+			}
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/services"
+)
+
+// StartTokenCleanup periodically removes expired entries from the token
+// revocation list, expired refresh tokens and expired sessions.
+func StartTokenCleanup(db *gorm.DB, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			deleted, err := services.CleanupRevokedTokens(db)
+			if err != nil {
+				log.Printf("Error cleaning up revoked tokens: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Removed %d expired revoked tokens", deleted)
+			}
+
+			deleted, err = services.CleanupRefreshTokens(db)
+			if err != nil {
+				log.Printf("Error cleaning up refresh tokens: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Removed %d expired refresh tokens", deleted)
+			}
+
+			deleted, err = services.CleanupSessions(db)
+			if err != nil {
+				log.Printf("Error cleaning up sessions: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Removed %d expired sessions", deleted)
+			}
+		}
+	}()
+}
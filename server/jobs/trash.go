@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/services"
+)
+
+// StartTrashPurge periodically deletes links and categories that have been
+// in the trash for longer than retention.
+func StartTrashPurge(db *gorm.DB, retention, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			links, categories, err := services.PurgeTrash(db, retention)
+			if err != nil {
+				log.Printf("Error purging trash: %v", err)
+				continue
+			}
+			if links > 0 || categories > 0 {
+				log.Printf("Purged %d links and %d categories from trash", links, categories)
+			}
+		}
+	}()
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	_ "raya/docs"
@@ -12,7 +13,10 @@ import (
 	"github.com/gin-contrib/cors"
 	"raya/config"
 	"raya/database"
+	"raya/jobs"
 	"raya/routes"
+	"raya/services"
+	"raya/utils"
 )
 
 // @title Raya API
@@ -25,13 +29,53 @@ func main() {
 		log.Fatal("Error loading .env file")
 	}
 
+	if err := utils.InitKeyRing(); err != nil {
+		log.Fatal(err)
+	}
+
 	db, err := config.InitDB()
 	if err != nil {
 		log.Fatal(err)
 	}
 	
 	if err := database.SeedDatabase(db); err != nil {
-		log.Printf("Error seeding database: %v", err)
+		log.Fatalf("Error seeding database: %v", err)
+	}
+
+	if err := services.LoadRevokedTokens(db); err != nil {
+		log.Fatal(err)
+	}
+	jobs.StartTokenCleanup(db, time.Hour)
+
+	retentionDays := 30
+	if value, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && value > 0 {
+		retentionDays = value
+	}
+	jobs.StartTrashPurge(db, time.Duration(retentionDays)*24*time.Hour, time.Hour)
+	jobs.StartPublishScheduler(db, time.Minute)
+
+	if url := os.Getenv("GOLD_PRICE_URL"); url != "" {
+		interval := 15 * time.Minute
+		if value, err := time.ParseDuration(os.Getenv("GOLD_PRICE_INTERVAL")); err == nil && value > 0 {
+			interval = value
+		}
+		jobs.StartPriceFeed(db, &services.HTTPPriceSource{URL: url}, interval)
+	}
+
+	// Exchange rates come from a live feed or, in development, a fixture
+	// such as database/fixtures/exchange_rates.json.
+	var rateSource services.RateSource
+	if url := os.Getenv("EXCHANGE_RATE_URL"); url != "" {
+		rateSource = &services.HTTPRateSource{URL: url}
+	} else if path := os.Getenv("EXCHANGE_RATE_FILE"); path != "" {
+		rateSource = &services.FileRateSource{Path: path}
+	}
+	if rateSource != nil {
+		interval := time.Hour
+		if value, err := time.ParseDuration(os.Getenv("EXCHANGE_RATE_INTERVAL")); err == nil && value > 0 {
+			interval = value
+		}
+		jobs.StartRateFeed(db, rateSource, interval)
 	}
 
 	router := routes.SetupRouter(db)
@@ -46,6 +90,7 @@ func main() {
 			"Content-Type", 
 			"Authorization", 
 			"Accept",
+			"X-API-Key",
 		},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
@@ -55,7 +100,7 @@ func main() {
 	router.OPTIONS("/*path", func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, X-API-Key")
 		c.Status(200)
 	})
 
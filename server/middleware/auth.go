@@ -2,14 +2,35 @@ package middleware
 
 import (
 	"net/http"
+	"raya/models"
+	"raya/services"
 	"raya/utils"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// AuthMiddleware authenticates an admin by Bearer token, or a script by the
+// X-API-Key header. For API keys "user" is the admin who created the key and
+// "api_key" holds the key itself.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			db := c.MustGet("db").(*gorm.DB)
+			key, creator, err := services.AuthenticateAPIKey(db, apiKey)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"message": "API key tidak valid"})
+				c.Abort()
+				return
+			}
+
+			c.Set("user", creator)
+			c.Set("api_key", key)
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "Authorization header required"})
@@ -25,14 +46,92 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		tokenString := tokenParts[1]
-		_, user, err := utils.ParseJWT(tokenString)
+		token, user, err := utils.ParseJWT(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
 			c.Abort()
 			return
 		}
 
-		c.Set("user", user)
+		jti, expiresAt, err := utils.GetTokenID(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			c.Abort()
+			return
+		}
+
+		if services.IsTokenRevoked(jti) {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "Token sudah tidak berlaku"})
+			c.Abort()
+			return
+		}
+
+		// Load the admin so role changes and deletions apply immediately
+		db := c.MustGet("db").(*gorm.DB)
+		admin, err := services.GetAdminByID(db, user.ID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "Admin tidak ditemukan"})
+			c.Abort()
+			return
+		}
+
+		sessionID := utils.GetSessionID(token)
+		if err := services.ValidateSession(db, sessionID, admin.ID, c.ClientIP()); err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "Sesi sudah berakhir"})
+			c.Abort()
+			return
+		}
+
+		if admin.MustChangePassword && !strings.HasSuffix(c.FullPath(), "/change-password") {
+			c.JSON(http.StatusForbidden, gin.H{
+				"message":              "Password harus diganti sebelum melanjutkan",
+				"must_change_password": true,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("user", admin)
+		c.Set("token_id", jti)
+		c.Set("token_expires_at", expiresAt)
+		c.Set("session_id", sessionID)
+		c.Next()
+	}
+}
+
+// RequirePermission rejects requests from admins whose role lacks permission.
+// API keys additionally need the permission among their scopes, so a key can
+// never do more than the admin who created it. It must run after
+// AuthMiddleware.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.MustGet("user").(*models.Admin)
+
+		allowed := models.RoleHasPermission(user.Role, permission)
+		if value, ok := c.Get("api_key"); ok {
+			allowed = allowed && value.(*models.APIKey).HasScope(permission)
+		}
+
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"message": "Anda tidak memiliki izin untuk aksi ini"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSession rejects API keys on routes that act on the admin's own
+// account, such as changing the password or logging out.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("api_key"); ok {
+			c.JSON(http.StatusForbidden, gin.H{"message": "Endpoint ini tidak dapat diakses dengan API key"})
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -6,6 +6,24 @@ type Admin struct {
 	ID               uint       `gorm:"primaryKey" json:"id"`
 	Username         string     `gorm:"uniqueIndex;not null" json:"username"`
 	Password         string     `gorm:"not null" json:"-"`	
+	Role             string     `gorm:"not null;default:viewer" json:"role"`
+	MustChangePassword bool     `gorm:"not null;default:false" json:"must_change_password"`
+	FailedLogins     int        `gorm:"not null;default:0" json:"failed_logins"`
+	LastFailedAt     *time.Time `json:"last_failed_at"`
+	LockedUntil      *time.Time `json:"locked_until"`
+	TOTPSecret       string     `json:"-"`
+	TOTPEnabled      bool       `gorm:"not null;default:false" json:"totp_enabled"`
+	TOTPLastStep     int64      `json:"-"`
 	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt        time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
-}
\ No newline at end of file
+}
+
+// RecoveryCode is a one-time code that can replace a TOTP code when the
+// admin has lost their authenticator.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	AdminID   uint       `gorm:"index;not null" json:"admin_id"`
+	CodeHash  string     `gorm:"not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
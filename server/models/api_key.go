@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// APIKey authenticates scripts through the X-API-Key header. Only a hash of
+// the key is stored; Prefix identifies the key in listings.
+type APIKey struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Name        string     `gorm:"not null" json:"name"`
+	Prefix      string     `gorm:"index;not null" json:"prefix"`
+	KeyHash     string     `gorm:"uniqueIndex;not null" json:"-"`
+	Scopes      []string   `gorm:"serializer:json" json:"scopes"`
+	CreatedByID uint       `gorm:"index;not null" json:"created_by_id"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
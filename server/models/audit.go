@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+const (
+	AuditActionCreate    = "create"
+	AuditActionUpdate    = "update"
+	AuditActionDelete    = "delete"
+	AuditActionRestore   = "restore"
+	AuditActionRollback  = "rollback"
+	AuditActionReorder   = "reorder"
+	AuditActionMove      = "move"
+	AuditActionDuplicate = "duplicate"
+	// Set by the publishing scheduler when a link's window opens or closes.
+	AuditActionPublish   = "publish"
+	AuditActionUnpublish = "unpublish"
+	// Set when a new gold price changes the price of an auto-priced link.
+	AuditActionReprice = "reprice"
+)
+
+const (
+	EntityLink     = "link"
+	EntityCategory = "category"
+)
+
+// AuditLog records one catalog mutation. Rows are never updated or deleted.
+type AuditLog struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ActorID       uint      `gorm:"index" json:"actor_id"`
+	ActorUsername string    `json:"actor_username"`
+	APIKeyID      *uint     `json:"api_key_id,omitempty"`
+	Action        string    `gorm:"index;not null" json:"action"`
+	EntityType    string    `gorm:"index:idx_audit_entity;not null" json:"entity_type"`
+	EntityID      uint      `gorm:"index:idx_audit_entity" json:"entity_id"`
+	Before        JSON      `gorm:"type:jsonb" json:"before"`
+	After         JSON      `gorm:"type:jsonb" json:"after"`
+	Diff          JSON      `gorm:"type:jsonb" json:"diff"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -1,25 +1,145 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
-	ID    uint   `gorm:"primaryKey" json:"id"`
-	Name  string `gorm:"notnull" json:"name"`
-	Order int    `json:"order"`
-	Links []Link `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"links,omitempty"`
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"notnull" json:"name"`
+	Order     int            `json:"order"`
+	Links     []Link         `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"links,omitempty"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
 }
 
 type Link struct {
-	ID         uint      `gorm:"primaryKey" json:"id"`
-	Title      string    `gorm:"not null" json:"title"`
-	URL        string    `gorm:"not null" json:"url"`
-	ImageURL   string    `json:"image_url"`
-	Price      int64     `json:"price"`
-	PriceStr   string    `json:"price_str"`
-	Order      int       `json:"order"`
-	IsActive   bool      `gorm:"default:true" json:"is_active"`
-	CategoryID uint      `json:"category_id"`
-	Category   *Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
\ No newline at end of file
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Title      string         `gorm:"not null" json:"title"`
+	URL        string         `gorm:"not null" json:"url"`
+	ImageURL   string         `json:"image_url"`
+	Price      int64          `json:"price"`
+	PriceStr   string         `json:"price_str"`
+	Order      int            `json:"order"`
+	IsActive   bool           `gorm:"default:true" json:"is_active"`
+	CategoryID uint           `json:"category_id"`
+	Category   *Category      `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
+
+	// Gold attributes. Zero values mean the attribute is not set.
+	WeightGrams float64 `gorm:"type:numeric(10,3);default:0" json:"weight_grams"`
+	Karat       int     `gorm:"index" json:"karat"`
+	ProductType string  `gorm:"index" json:"product_type"`
+	Brand       string  `json:"brand"`
+	SKU         string  `json:"sku"`
+
+	// PriceStr is rendered from Price, as a starting price when PriceFrom is
+	// set, unless PriceStrOverride keeps it as written.
+	PriceFrom        bool `gorm:"default:false" json:"price_from"`
+	PriceStrOverride bool `gorm:"default:false" json:"price_str_override"`
+
+	// With AutoPrice set, Price and PriceStr follow the gold price: the
+	// link's weight times the latest sell price per gram for its karat, plus
+	// MakingFee.
+	AutoPrice bool  `gorm:"default:false" json:"auto_price"`
+	MakingFee int64 `gorm:"default:0" json:"making_fee"`
+
+	// A promo sells the link below Price between PromoStartAt and
+	// PromoEndAt, either of which may be nil: at SalePrice, or when that is 0
+	// at DiscountPercent off Price so the sale follows price changes.
+	SalePrice       int64      `gorm:"default:0" json:"sale_price"`
+	DiscountPercent int        `gorm:"default:0" json:"discount_percent"`
+	PromoStartAt    *time.Time `json:"promo_start_at"`
+	PromoEndAt      *time.Time `json:"promo_end_at"`
+	// Promo is the promo in effect when the link was loaded, nil outside the
+	// promo window.
+	Promo *LinkPromo `gorm:"-" json:"promo"`
+	// DisplayPrice is set on the public catalog when another currency is
+	// asked for.
+	DisplayPrice *DisplayPrice `gorm:"-" json:"display_price,omitempty"`
+
+	// PublishAt and UnpublishAt bound when the link is shown on the public
+	// catalog. Either may be nil for an open-ended window.
+	PublishAt   *time.Time `gorm:"index" json:"publish_at"`
+	UnpublishAt *time.Time `gorm:"index" json:"unpublish_at"`
+	// ScheduleState is the window status when the link was last saved or
+	// acted on by the scheduler. Only a change from it is acted on, so a
+	// link switched off by hand inside its window stays off.
+	ScheduleState string `json:"-"`
+	Status        string `gorm:"-" json:"status"`
+}
+
+const (
+	LinkStatusScheduled = "scheduled"
+	LinkStatusLive      = "live"
+	LinkStatusExpired   = "expired"
+	LinkStatusInactive  = "inactive"
+)
+
+// WindowStatus reports where now falls within the link's publishing window,
+// regardless of IsActive.
+func (l *Link) WindowStatus(now time.Time) string {
+	if l.UnpublishAt != nil && !now.Before(*l.UnpublishAt) {
+		return LinkStatusExpired
+	}
+	if l.PublishAt != nil && now.Before(*l.PublishAt) {
+		return LinkStatusScheduled
+	}
+	return LinkStatusLive
+}
+
+// LinkPromo is the computed price of a link during its promo.
+// SalePriceStr is filled in for the public catalog.
+type LinkPromo struct {
+	OriginalPrice   int64      `json:"original_price"`
+	SalePrice       int64      `json:"sale_price"`
+	DiscountPercent int        `json:"discount_percent"`
+	SalePriceStr    string     `json:"sale_price_str,omitempty"`
+	EndsAt          *time.Time `json:"ends_at"`
+}
+
+// PromoAt returns the promo in effect at now. A sale price that is not below
+// Price, which can happen once an auto-priced link gets cheaper, is no
+// promo.
+func (l *Link) PromoAt(now time.Time) *LinkPromo {
+	if (l.PromoStartAt != nil && now.Before(*l.PromoStartAt)) || (l.PromoEndAt != nil && !now.Before(*l.PromoEndAt)) {
+		return nil
+	}
+
+	sale := l.SalePrice
+	if sale == 0 && l.DiscountPercent > 0 {
+		sale = l.Price - l.Price*int64(l.DiscountPercent)/100
+	}
+	if sale <= 0 || sale >= l.Price {
+		return nil
+	}
+
+	return &LinkPromo{
+		OriginalPrice:   l.Price,
+		SalePrice:       sale,
+		DiscountPercent: int((l.Price - sale) * 100 / l.Price),
+		EndsAt:          l.PromoEndAt,
+	}
+}
+
+func (l *Link) AfterFind(tx *gorm.DB) error {
+	l.setStatus()
+	return nil
+}
+
+func (l *Link) AfterSave(tx *gorm.DB) error {
+	l.setStatus()
+	return nil
+}
+
+func (l *Link) setStatus() {
+	now := time.Now()
+	l.Promo = l.PromoAt(now)
+	l.Status = l.WindowStatus(now)
+	if l.Status == LinkStatusLive && !l.IsActive {
+		l.Status = LinkStatusInactive
+	}
+}
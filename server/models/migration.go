@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// SchemaMigration marks a one-time data migration as done.
+type SchemaMigration struct {
+	Name      string    `gorm:"primaryKey"`
+	AppliedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,63 @@
+package models
+
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+const (
+	PermLinksRead       = "links:read"
+	PermLinksWrite      = "links:write"
+	PermCategoriesRead  = "categories:read"
+	PermCategoriesWrite = "categories:write"
+	PermAdminsManage    = "admins:manage"
+	PermAPIKeysManage   = "api_keys:manage"
+	PermAuditRead       = "audit:read"
+)
+
+// APIKeyScopes are the permissions that may be granted to an API key.
+var APIKeyScopes = []string{
+	PermLinksRead, PermLinksWrite,
+	PermCategoriesRead, PermCategoriesWrite,
+}
+
+var RolePermissions = map[string][]string{
+	RoleOwner: {
+		PermLinksRead, PermLinksWrite,
+		PermCategoriesRead, PermCategoriesWrite,
+		PermAdminsManage, PermAPIKeysManage,
+		PermAuditRead,
+	},
+	RoleEditor: {
+		PermLinksRead, PermLinksWrite,
+		PermCategoriesRead, PermCategoriesWrite,
+	},
+	RoleViewer: {
+		PermLinksRead,
+		PermCategoriesRead,
+	},
+}
+
+func IsValidRole(role string) bool {
+	_, ok := RolePermissions[role]
+	return ok
+}
+
+func RoleHasPermission(role, permission string) bool {
+	for _, p := range RolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidAPIKeyScope(scope string) bool {
+	for _, s := range APIKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
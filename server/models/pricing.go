@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// GoldQuote is the price of one gram of gold of a karat at a point in time.
+// SellPerGram is what the shop charges a customer and prices the catalog;
+// BuyPerGram is what it pays when buying gold back.
+type GoldQuote struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Karat       int       `gorm:"not null;index:idx_gold_quotes_karat_time,priority:1" json:"karat"`
+	BuyPerGram  int64     `gorm:"not null" json:"buy_per_gram"`
+	SellPerGram int64     `gorm:"not null" json:"sell_per_gram"`
+	Source      string    `gorm:"not null" json:"source"`
+	QuotedAt    time.Time `gorm:"not null;index:idx_gold_quotes_karat_time,priority:2" json:"quoted_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// PriceChange records that a link's price became Price at ChangedAt. The
+// rows of a link form its price history.
+type PriceChange struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	LinkID    uint      `gorm:"not null;index:idx_price_changes_link_time,priority:1" json:"-"`
+	Price     int64     `gorm:"not null" json:"price"`
+	ChangedAt time.Time `gorm:"not null;index:idx_price_changes_link_time,priority:2" json:"changed_at"`
+}
+
+// ExchangeRate is what one unit of Currency costs in rupiah at RatedAt.
+type ExchangeRate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Currency  string    `gorm:"size:3;not null;index:idx_exchange_rates_currency_time,priority:1" json:"currency"`
+	IDRRate   float64   `gorm:"not null" json:"idr_rate"`
+	Source    string    `gorm:"not null" json:"source"`
+	RatedAt   time.Time `gorm:"not null;index:idx_exchange_rates_currency_time,priority:2" json:"rated_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// DisplayPrice is a price converted from rupiah for display. Price stays
+// the canonical amount.
+type DisplayPrice struct {
+	Currency     string    `json:"currency"`
+	Price        float64   `json:"price"`
+	PriceStr     string    `json:"price_str"`
+	SalePrice    *float64  `json:"sale_price,omitempty"`
+	SalePriceStr string    `json:"sale_price_str,omitempty"`
+	Rate         float64   `json:"rate"`
+	RatedAt      time.Time `json:"rated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+const (
+	ProductTypeRing     = "ring"
+	ProductTypeBracelet = "bracelet"
+	ProductTypeBangle   = "bangle"
+	ProductTypeNecklace = "necklace"
+	ProductTypePendant  = "pendant"
+	ProductTypeEarring  = "earring"
+	ProductTypeAnklet   = "anklet"
+	ProductTypeBar      = "bar"
+	ProductTypeCoin     = "coin"
+	ProductTypeOther    = "other"
+)
+
+var ProductTypes = []string{
+	ProductTypeRing, ProductTypeBracelet, ProductTypeBangle,
+	ProductTypeNecklace, ProductTypePendant, ProductTypeEarring,
+	ProductTypeAnklet, ProductTypeBar, ProductTypeCoin, ProductTypeOther,
+}
+
+// MaxKarat is pure gold.
+const MaxKarat = 24
+
+func IsValidProductType(productType string) bool {
+	for _, t := range ProductTypes {
+		if t == productType {
+			return true
+		}
+	}
+	return false
+}
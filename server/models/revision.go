@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// RevisionActionImport marks the revision that stores the state an entity
+// had before revisions were kept.
+const RevisionActionImport = "import"
+
+// Revision is a numbered snapshot of a link or category after a change.
+type Revision struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	EntityType    string    `gorm:"uniqueIndex:idx_revision_entity_number;not null" json:"entity_type"`
+	EntityID      uint      `gorm:"uniqueIndex:idx_revision_entity_number;not null" json:"entity_id"`
+	Number        int       `gorm:"uniqueIndex:idx_revision_entity_number;not null" json:"number"`
+	Action        string    `gorm:"not null" json:"action"`
+	Snapshot      JSON      `gorm:"type:jsonb;not null" json:"snapshot"`
+	ActorID       uint      `json:"actor_id"`
+	ActorUsername string    `json:"actor_username"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type RevokedToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	JTI       string    `gorm:"uniqueIndex;not null" json:"jti"`
+	AdminID   uint      `gorm:"index" json:"admin_id"`
+	ExpiresAt time.Time `gorm:"index;not null" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// RefreshToken is one link in a rotation chain. Tokens issued from the same
+// login share a FamilyID so that replaying a rotated token can revoke the
+// whole chain.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	AdminID   uint       `gorm:"index;not null" json:"admin_id"`
+	FamilyID  string     `gorm:"index;not null" json:"family_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"index;not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Session is one signed-in device. It shares its FamilyID with the refresh
+// tokens issued to that device and with the "sid" claim of its access tokens.
+type Session struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	AdminID    uint       `gorm:"index;not null" json:"admin_id"`
+	FamilyID   string     `gorm:"uniqueIndex;not null" json:"-"`
+	Device     string     `json:"device"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"issued_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `gorm:"index;not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	Current    bool       `gorm:"-" json:"current"`
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+func GetAdmins(db *gorm.DB) ([]models.Admin, error) {
+	var admins []models.Admin
+	err := db.Order("id asc").Find(&admins).Error
+	return admins, err
+}
+
+func CreateAdmin(db *gorm.DB, admin *models.Admin) error {
+	return db.Create(admin).Error
+}
+
+func UpdateAdmin(db *gorm.DB, admin *models.Admin) error {
+	return db.Save(admin).Error
+}
+
+func DeleteAdmin(db *gorm.DB, id uint) error {
+	return db.Delete(&models.Admin{}, id).Error
+}
+
+func CountAdminsByRole(db *gorm.DB, role string) (int64, error) {
+	var count int64
+	err := db.Model(&models.Admin{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
+func RevokeAdminRefreshTokens(db *gorm.DB, adminID uint) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("admin_id = ? AND revoked_at IS NULL", adminID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+func GetAPIKeys(db *gorm.DB) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := db.Order("created_at desc").Find(&keys).Error
+	return keys, err
+}
+
+func GetAPIKeyByHash(db *gorm.DB, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := db.Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func CreateAPIKey(db *gorm.DB, key *models.APIKey) error {
+	return db.Create(key).Error
+}
+
+func RevokeAPIKey(db *gorm.DB, id uint) error {
+	result := db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func TouchAPIKey(db *gorm.DB, id uint, usedAt time.Time) error {
+	return db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
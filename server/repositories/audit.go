@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+func CreateAuditLog(db *gorm.DB, entry *models.AuditLog) error {
+	return db.Create(entry).Error
+}
+
+func GetAuditLogs(db *gorm.DB, filter AuditFilter) ([]models.AuditLog, int64, error) {
+	query := db.Model(&models.AuditLog{})
+
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AuditLog
+	err := query.Order("created_at desc, id desc").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&logs).Error
+
+	return logs, total, err
+}
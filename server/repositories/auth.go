@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 	"raya/models"
 )
@@ -23,5 +25,23 @@ func GetUserByID(db *gorm.DB, userID uint) (*models.Admin, error) {
 }
 
 func UpdatePassword(db *gorm.DB, userID uint, newPassword string) error {
-	return db.Model(&models.Admin{}).Where("id = ?", userID).Update("password", newPassword).Error
+	return db.Model(&models.Admin{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"password":             newPassword,
+		"must_change_password": false,
+	}).Error
+}
+
+func RecordFailedLogin(db *gorm.DB, userID uint, failedLogins int, lockedUntil *time.Time) error {
+	return db.Model(&models.Admin{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"failed_logins":  failedLogins,
+		"last_failed_at": time.Now(),
+		"locked_until":   lockedUntil,
+	}).Error
+}
+
+func ResetFailedLogins(db *gorm.DB, userID uint) error {
+	return db.Model(&models.Admin{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"failed_logins": 0,
+		"locked_until":  nil,
+	}).Error
 }
\ No newline at end of file
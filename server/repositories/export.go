@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// exportBatchSize bounds how many rows are held in memory while streaming an
+// export.
+const exportBatchSize = 500
+
+// EachCategory calls fn for every category, loading them in batches.
+func EachCategory(db *gorm.DB, fn func(*models.Category) error) error {
+	var batch []models.Category
+	return db.FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// EachLink calls fn for every link, loading them in batches.
+func EachLink(db *gorm.DB, fn func(*models.Link) error) error {
+	var batch []models.Link
+	return db.FindInBatches(&batch, exportBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// CountCatalog counts categories and links, trashed ones included.
+func CountCatalog(db *gorm.DB) (int64, error) {
+	var categories, links int64
+	if err := db.Unscoped().Model(&models.Category{}).Count(&categories).Error; err != nil {
+		return 0, err
+	}
+	if err := db.Unscoped().Model(&models.Link{}).Count(&links).Error; err != nil {
+		return 0, err
+	}
+	return categories + links, nil
+}
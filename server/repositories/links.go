@@ -42,7 +42,7 @@ func GetNextLinkOrder(db *gorm.DB, categoryID uint) (int, error) {
 
 func GetCategories(db *gorm.DB) ([]models.Category, error) {
 	var categories []models.Category
-	err := db.Select("id, name, \"order\"").Find(&categories).Error
+	err := db.Select("id, name, \"order\"").Order("\"order\" asc").Find(&categories).Error
 	
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return []models.Category{}, nil
@@ -60,7 +60,7 @@ func GetAllCategories(db *gorm.DB, includeEmpty bool) ([]models.Category, error)
 		Order("links.\"order\" asc")
 
 	if !includeEmpty {
-		query = query.Joins("JOIN links ON links.category_id = categories.id AND links.is_active = ?", true).
+		query = query.Joins("JOIN links ON links.category_id = categories.id AND links.is_active = ? AND links.deleted_at IS NULL", true).
 			Group("categories.id")
 	}
 
@@ -74,29 +74,19 @@ func GetAllCategories(db *gorm.DB, includeEmpty bool) ([]models.Category, error)
 	return categories, nil
 }
 
-func GetLinks(db *gorm.DB) ([]models.Link, error) {
+// GetCategoryLinks returns all links of a category, active or not, by position.
+func GetCategoryLinks(db *gorm.DB, categoryID uint) ([]models.Link, error) {
 	var links []models.Link
-	err := db.Find(&links).Error
+	err := db.Where("category_id = ?", categoryID).Order("\"order\" asc").Find(&links).Error
 	return links, err
 }
 
-func GetAllLinks(db *gorm.DB) ([]models.Link, error) {
-	var links []models.Link
-	err := db.Preload("Category").Order("category_id, \"order\"").Find(&links).Error
-	
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		return []models.Link{}, nil
-	}
-	
-	return links, err
-}
-
-func GetCategoriesWithLinks(db *gorm.DB) ([]models.Category, error) {
+func GetCategoriesWithLinks(db *gorm.DB, filter GoldFilter) ([]models.Category, error) {
     var categories []models.Category
     
-    // Preload active links and order them appropriately
+    // Preload active links within their publishing window and order them appropriately
     err := db.Preload("Links", func(db *gorm.DB) *gorm.DB {
-        return db.Order("\"order\" asc")
+        return db.Scopes(VisibleLinks(time.Now()), filter.Scope).Order("\"order\" asc")
     }).Order("\"order\" asc").Find(&categories).Error
     
     if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
@@ -114,7 +104,29 @@ func GetLinkByID(db *gorm.DB, id uint) (*models.Link, error) {
 	return &link, nil
 }
 
+// GetLinkBySKU returns the live link with the given SKU.
+func GetLinkBySKU(db *gorm.DB, sku string) (*models.Link, error) {
+	var link models.Link
+	if err := db.Where("sku = ?", sku).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetLinkByURL returns the oldest link with the given URL.
+func GetLinkByURL(db *gorm.DB, url string) (*models.Link, error) {
+	var link models.Link
+	if err := db.Where("url = ?", url).Order("id asc").First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
 func CreateLink(db *gorm.DB, link *models.Link) error {
+	if err := LockOrdering(db, link.CategoryID); err != nil {
+		return err
+	}
+
 	if link.Order <= 0 {
 		nextOrder, err := GetNextLinkOrder(db, link.CategoryID)
 		if err != nil {
@@ -123,14 +135,29 @@ func CreateLink(db *gorm.DB, link *models.Link) error {
 		link.Order = nextOrder
 	}
 	
-	return db.Create(link).Error
+	if err := db.Create(link).Error; err != nil {
+		return err
+	}
+	if err := recordPriceChange(db, link.ID, link.Price, link.CreatedAt); err != nil {
+		return err
+	}
+
+	// GORM leaves a false IsActive to the column default, which is true.
+	if !link.IsActive {
+		return db.Model(link).Update("is_active", false).Error
+	}
+	return nil
 }
 
+// UpdateLink copies the editable fields of updatedLink and records a new
+// price in the price history. It never changes Order; callers that move the
+// link re-sequence positions themselves.
 func UpdateLink(db *gorm.DB, id uint, updatedLink *models.Link) error {
 	link, err := GetLinkByID(db, id)
 	if err != nil {
 		return err
 	}
+	priceChanged := link.Price != updatedLink.Price
 
 	link.Title = updatedLink.Title
 	link.URL = updatedLink.URL
@@ -139,10 +166,32 @@ func UpdateLink(db *gorm.DB, id uint, updatedLink *models.Link) error {
 	link.PriceStr = updatedLink.PriceStr
 	link.CategoryID = updatedLink.CategoryID
 	link.IsActive = updatedLink.IsActive
-	link.Order = updatedLink.Order
+	link.WeightGrams = updatedLink.WeightGrams
+	link.Karat = updatedLink.Karat
+	link.ProductType = updatedLink.ProductType
+	link.Brand = updatedLink.Brand
+	link.SKU = updatedLink.SKU
+	link.PriceFrom = updatedLink.PriceFrom
+	link.PriceStrOverride = updatedLink.PriceStrOverride
+	link.SalePrice = updatedLink.SalePrice
+	link.DiscountPercent = updatedLink.DiscountPercent
+	link.PromoStartAt = updatedLink.PromoStartAt
+	link.PromoEndAt = updatedLink.PromoEndAt
+	link.AutoPrice = updatedLink.AutoPrice
+	link.MakingFee = updatedLink.MakingFee
 	link.UpdatedAt = time.Now()
 
-	return db.Save(link).Error
+	link.PublishAt = updatedLink.PublishAt
+	link.UnpublishAt = updatedLink.UnpublishAt
+	link.ScheduleState = updatedLink.ScheduleState
+
+	if err := db.Save(link).Error; err != nil {
+		return err
+	}
+	if priceChanged {
+		return recordPriceChange(db, id, link.Price, link.UpdatedAt)
+	}
+	return nil
 }
 
 func DeleteLink(db *gorm.DB, id uint) error {
@@ -158,6 +207,10 @@ func GetCategoryByID(db *gorm.DB, id uint) (*models.Category, error) {
 }
 
 func CreateCategory(db *gorm.DB, category *models.Category) error {
+	if err := LockOrdering(db, 0); err != nil {
+		return err
+	}
+
 	// Set order secara otomatis jika tidak disediakan
 	if category.Order <= 0 {
 		nextOrder, err := GetNextCategoryOrder(db)
@@ -170,6 +223,8 @@ func CreateCategory(db *gorm.DB, category *models.Category) error {
 	return db.Create(category).Error
 }
 
+// UpdateCategory copies the editable fields of updatedCategory. Positions only
+// change through ReorderCategories.
 func UpdateCategory(db *gorm.DB, id uint, updatedCategory *models.Category) error {
 	category, err := GetCategoryByID(db, id)
 	if err != nil {
@@ -177,11 +232,19 @@ func UpdateCategory(db *gorm.DB, id uint, updatedCategory *models.Category) erro
 	}
 
 	category.Name = updatedCategory.Name
-	category.Order = updatedCategory.Order
 
 	return db.Save(category).Error
 }
 
+// DeleteCategory soft-deletes a category together with its links. The links
+// get the category's deletion time so RestoreCategory can tell them apart
+// from links that were trashed earlier on their own.
 func DeleteCategory(db *gorm.DB, id uint) error {
-	return db.Delete(&models.Category{}, id).Error
+	now := time.Now()
+
+	if err := db.Model(&models.Link{}).Where("category_id = ?", id).Update("deleted_at", now).Error; err != nil {
+		return err
+	}
+
+	return db.Model(&models.Category{}).Where("id = ?", id).Update("deleted_at", now).Error
 }
\ No newline at end of file
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// GoldFilter narrows links by their gold attributes. It is shared by the
+// admin lists and the public catalog.
+type GoldFilter struct {
+	ProductType string
+	Karat       int
+	Brand       string
+	WeightMin   *float64
+	WeightMax   *float64
+}
+
+func (f GoldFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f.ProductType != "" {
+		db = db.Where("product_type = ?", f.ProductType)
+	}
+	if f.Karat != 0 {
+		db = db.Where("karat = ?", f.Karat)
+	}
+	if f.Brand != "" {
+		db = db.Where("brand ILIKE ?", likePattern(f.Brand))
+	}
+	if f.WeightMin != nil {
+		db = db.Where("weight_grams >= ?", *f.WeightMin)
+	}
+	if f.WeightMax != nil {
+		db = db.Where("weight_grams <= ?", *f.WeightMax)
+	}
+	return db
+}
+
+// LinkFilter narrows the admin link lists. Sort holds ORDER BY clauses that
+// the caller has already checked against the allowed columns.
+type LinkFilter struct {
+	GoldFilter
+	CategoryID  uint
+	IsActive    *bool
+	PriceMin    *int64
+	PriceMax    *int64
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	SKU         string
+	Query       string
+	Sort        []string
+	Offset      int
+	Limit       int
+}
+
+type CategoryFilter struct {
+	Query  string
+	Sort   []string
+	Offset int
+	Limit  int
+}
+
+func GetLinks(db *gorm.DB, filter LinkFilter) ([]models.Link, int64, error) {
+	return findLinks(db, filter, false)
+}
+
+// GetAllLinks is GetLinks with each link's category preloaded.
+func GetAllLinks(db *gorm.DB, filter LinkFilter) ([]models.Link, int64, error) {
+	return findLinks(db, filter, true)
+}
+
+func findLinks(db *gorm.DB, filter LinkFilter, withCategory bool) ([]models.Link, int64, error) {
+	query := db.Model(&models.Link{}).Scopes(filter.GoldFilter.Scope)
+
+	if filter.CategoryID != 0 {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.PriceMin != nil {
+		query = query.Where("price >= ?", *filter.PriceMin)
+	}
+	if filter.PriceMax != nil {
+		query = query.Where("price <= ?", *filter.PriceMax)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedTo)
+	}
+	if filter.SKU != "" {
+		query = query.Where("sku = ?", filter.SKU)
+	}
+	if filter.Query != "" {
+		pattern := likePattern(filter.Query)
+		query = query.Where("title ILIKE ? OR url ILIKE ?", pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, clause := range filter.Sort {
+		query = query.Order(clause)
+	}
+
+	if withCategory {
+		query = query.Preload("Category")
+	}
+
+	var links []models.Link
+	err := query.Offset(filter.Offset).Limit(filter.Limit).Find(&links).Error
+	return links, total, err
+}
+
+func GetCategoryList(db *gorm.DB, filter CategoryFilter) ([]models.Category, int64, error) {
+	query := db.Model(&models.Category{})
+
+	if filter.Query != "" {
+		query = query.Where("name ILIKE ?", likePattern(filter.Query))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, clause := range filter.Sort {
+		query = query.Order(clause)
+	}
+
+	var categories []models.Category
+	err := query.Offset(filter.Offset).Limit(filter.Limit).Find(&categories).Error
+	return categories, total, err
+}
+
+// likePattern matches value anywhere in a column, treating LIKE wildcards in
+// value as literal characters.
+func likePattern(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return "%" + value + "%"
+}
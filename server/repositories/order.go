@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// orderLockNamespace keeps the advisory locks taken by LockOrdering apart from
+// any other advisory locks on the database.
+const orderLockNamespace int64 = 0x5345_4b57
+
+// LockOrdering serialises changes to the positions of one list until the
+// transaction ends. The category list uses ID 0 and the links of a category
+// use the category's ID.
+func LockOrdering(db *gorm.DB, categoryID uint) error {
+	return db.Exec("SELECT pg_advisory_xact_lock(?)", orderLockNamespace<<32|int64(categoryID)).Error
+}
+
+func GetCategoryIDs(db *gorm.DB) ([]uint, error) {
+	var ids []uint
+	err := db.Model(&models.Category{}).Order("\"order\" asc, id asc").Pluck("id", &ids).Error
+	return ids, err
+}
+
+func GetLinkIDs(db *gorm.DB, categoryID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Model(&models.Link{}).Where("category_id = ?", categoryID).
+		Order("\"order\" asc, id asc").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// SetLinkCategory puts a link into another category at the given position.
+func SetLinkCategory(db *gorm.DB, id, categoryID uint, order int) error {
+	return db.Model(&models.Link{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"category_id": categoryID, "order": order}).Error
+}
+
+// ReorderCategories gives the categories in ids the positions 1..n.
+func ReorderCategories(db *gorm.DB, ids []uint) error {
+	return reorder(db, &models.Category{}, ids)
+}
+
+// ReorderLinks gives the links in ids the positions 1..n. The caller makes
+// sure ids are exactly the links of one category.
+func ReorderLinks(db *gorm.DB, ids []uint) error {
+	return reorder(db, &models.Link{}, ids)
+}
+
+// reorder first parks every row of the list on a negative position, so the
+// unique index never sees two rows on the same position halfway through.
+func reorder(db *gorm.DB, model interface{}, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := db.Model(model).Where("id IN ?", ids).UpdateColumn("order", gorm.Expr("-id")).Error; err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if err := db.Model(model).Where("id = ?", id).UpdateColumn("order", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
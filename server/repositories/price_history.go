@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// DailyGoldPrice sums up the quotes of one karat on one day. Close prices
+// are those of the day's last quote.
+type DailyGoldPrice struct {
+	Day       string `json:"day"`
+	SellLow   int64  `json:"sell_low"`
+	SellHigh  int64  `json:"sell_high"`
+	SellClose int64  `json:"sell_close"`
+	BuyClose  int64  `json:"buy_close"`
+	Quotes    int    `json:"quotes"`
+}
+
+func recordPriceChange(db *gorm.DB, linkID uint, price int64, changedAt time.Time) error {
+	return db.Create(&models.PriceChange{LinkID: linkID, Price: price, ChangedAt: changedAt}).Error
+}
+
+// GetPriceChanges returns the price changes of a link in [from, to), oldest
+// first, led by the change that set the price in effect at from.
+func GetPriceChanges(db *gorm.DB, linkID uint, from, to *time.Time) ([]models.PriceChange, error) {
+	query := db.Where("link_id = ?", linkID)
+	if from != nil {
+		var start models.PriceChange
+		err := db.Where("link_id = ? AND changed_at <= ?", linkID, *from).
+			Order("changed_at desc, id desc").First(&start).Error
+		switch {
+		case err == nil:
+			query = query.Where("changed_at >= ?", start.ChangedAt)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			query = query.Where("changed_at >= ?", *from)
+		default:
+			return nil, err
+		}
+	}
+	if to != nil {
+		query = query.Where("changed_at < ?", *to)
+	}
+
+	var changes []models.PriceChange
+	err := query.Order("changed_at asc, id asc").Find(&changes).Error
+	return changes, err
+}
+
+// GetDailyGoldPrices groups the quotes of a karat in [from, to) by day in
+// the given time zone.
+func GetDailyGoldPrices(db *gorm.DB, karat int, from, to time.Time, timeZone string) ([]DailyGoldPrice, error) {
+	var days []DailyGoldPrice
+	err := db.Raw(`
+		SELECT to_char(quoted_at AT TIME ZONE ?, 'YYYY-MM-DD') AS day,
+			MIN(sell_per_gram) AS sell_low,
+			MAX(sell_per_gram) AS sell_high,
+			(array_agg(sell_per_gram ORDER BY quoted_at DESC, id DESC))[1] AS sell_close,
+			(array_agg(buy_per_gram ORDER BY quoted_at DESC, id DESC))[1] AS buy_close,
+			COUNT(*) AS quotes
+		FROM gold_quotes
+		WHERE karat = ? AND quoted_at >= ? AND quoted_at < ?
+		GROUP BY day
+		ORDER BY day`,
+		timeZone, karat, from, to,
+	).Scan(&days).Error
+	return days, err
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+type GoldQuoteFilter struct {
+	Karat  int
+	From   *time.Time
+	To     *time.Time
+	Offset int
+	Limit  int
+}
+
+func CreateGoldQuote(db *gorm.DB, quote *models.GoldQuote) error {
+	return db.Create(quote).Error
+}
+
+// GetLatestGoldQuote returns the most recent quote for a karat.
+func GetLatestGoldQuote(db *gorm.DB, karat int) (*models.GoldQuote, error) {
+	var quote models.GoldQuote
+	err := db.Where("karat = ?", karat).Order("quoted_at desc, id desc").First(&quote).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// GetLatestGoldQuotes returns the most recent quote of every karat.
+func GetLatestGoldQuotes(db *gorm.DB) ([]models.GoldQuote, error) {
+	var quotes []models.GoldQuote
+	err := db.Raw(`SELECT DISTINCT ON (karat) * FROM gold_quotes
+		ORDER BY karat DESC, quoted_at DESC, id DESC`).Scan(&quotes).Error
+	return quotes, err
+}
+
+func GetGoldQuotes(db *gorm.DB, filter GoldQuoteFilter) ([]models.GoldQuote, int64, error) {
+	query := db.Model(&models.GoldQuote{})
+
+	if filter.Karat != 0 {
+		query = query.Where("karat = ?", filter.Karat)
+	}
+	if filter.From != nil {
+		query = query.Where("quoted_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("quoted_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var quotes []models.GoldQuote
+	err := query.Order("quoted_at desc, id desc").
+		Offset(filter.Offset).
+		Limit(filter.Limit).
+		Find(&quotes).Error
+
+	return quotes, total, err
+}
+
+// GetAutoPricedLinks returns the links of a karat whose price follows the
+// gold price.
+func GetAutoPricedLinks(db *gorm.DB, karat int) ([]models.Link, error) {
+	var links []models.Link
+	err := db.Where("auto_price = ? AND karat = ? AND weight_grams > 0", true, karat).Find(&links).Error
+	return links, err
+}
+
+// UpdateLinkPrice stores a computed price without touching the link's other
+// fields and records it in the price history.
+func UpdateLinkPrice(db *gorm.DB, id uint, price int64, priceStr string) error {
+	now := time.Now()
+	err := db.Model(&models.Link{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"price": price, "price_str": priceStr, "updated_at": now}).Error
+	if err != nil {
+		return err
+	}
+	return recordPriceChange(db, id, price, now)
+}
+
+func CreateExchangeRate(db *gorm.DB, rate *models.ExchangeRate) error {
+	return db.Create(rate).Error
+}
+
+// GetLatestExchangeRate returns the most recent rate of a currency.
+func GetLatestExchangeRate(db *gorm.DB, currency string) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := db.Where("currency = ?", currency).Order("rated_at desc, id desc").First(&rate).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// GetLatestExchangeRates returns the most recent rate of every currency.
+func GetLatestExchangeRates(db *gorm.DB) ([]models.ExchangeRate, error) {
+	var rates []models.ExchangeRate
+	err := db.Raw(`SELECT DISTINCT ON (currency) * FROM exchange_rates
+		ORDER BY currency, rated_at DESC, id DESC`).Scan(&rates).Error
+	return rates, err
+}
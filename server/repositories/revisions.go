@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"raya/models"
+)
+
+// LockEntity locks the row of a link or category until the transaction
+// ends, so concurrent changes to it take revision numbers one at a time.
+func LockEntity(db *gorm.DB, entityType string, entityID uint) error {
+	var model interface{}
+	switch entityType {
+	case models.EntityLink:
+		model = &models.Link{}
+	case models.EntityCategory:
+		model = &models.Category{}
+	default:
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+
+	var ids []uint
+	return db.Unscoped().Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", entityID).Pluck("id", &ids).Error
+}
+
+func CreateRevision(db *gorm.DB, revision *models.Revision) error {
+	return db.Create(revision).Error
+}
+
+func GetRevisions(db *gorm.DB, entityType string, entityID uint) ([]models.Revision, error) {
+	var revisions []models.Revision
+	err := db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("number desc").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func GetRevision(db *gorm.DB, entityType string, entityID uint, number int) (*models.Revision, error) {
+	var revision models.Revision
+	err := db.Where("entity_type = ? AND entity_id = ? AND number = ?", entityType, entityID, number).
+		First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func GetLatestRevisionNumber(db *gorm.DB, entityType string, entityID uint) (int, error) {
+	var latest struct {
+		Number int
+	}
+
+	err := db.Model(&models.Revision{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Select("COALESCE(MAX(number), 0) as number").
+		Scan(&latest).Error
+
+	return latest.Number, err
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// VisibleLinks limits a links query to active links whose publishing window
+// contains now.
+func VisibleLinks(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("is_active = ?", true).
+			Where("publish_at IS NULL OR publish_at <= ?", now).
+			Where("unpublish_at IS NULL OR unpublish_at > ?", now)
+	}
+}
+
+// GetVisibleLinkByID returns a link only while it is shown on the public
+// catalog.
+func GetVisibleLinkByID(db *gorm.DB, id uint) (*models.Link, error) {
+	var link models.Link
+	if err := db.Scopes(VisibleLinks(time.Now())).First(&link, id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetScheduledLinks returns the links whose window status at now differs
+// from the one stored with them.
+func GetScheduledLinks(db *gorm.DB, now time.Time) ([]models.Link, error) {
+	var links []models.Link
+	err := db.Where("publish_at IS NOT NULL OR unpublish_at IS NOT NULL").
+		Where(`schedule_state <> CASE
+			WHEN unpublish_at IS NOT NULL AND unpublish_at <= ? THEN ?
+			WHEN publish_at IS NOT NULL AND publish_at > ? THEN ?
+			ELSE ? END`,
+			now, models.LinkStatusExpired, now, models.LinkStatusScheduled, models.LinkStatusLive).
+		Find(&links).Error
+	return links, err
+}
+
+// ApplyScheduleState stores the window status the scheduler acted on and the
+// resulting active flag.
+func ApplyScheduleState(db *gorm.DB, id uint, state string, isActive bool) error {
+	return db.Model(&models.Link{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"schedule_state": state, "is_active": isActive}).Error
+}
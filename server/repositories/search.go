@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+// SearchHit is a visible link matching a search, with its category name.
+// Snippet is the title with the matched words between the markers passed to
+// the search.
+type SearchHit struct {
+	ID           uint    `json:"id"`
+	Title        string  `json:"title"`
+	URL          string  `json:"url"`
+	ImageURL     string  `json:"image_url"`
+	Price        int64   `json:"price"`
+	PriceStr     string  `json:"price_str"`
+	CategoryID   uint    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Rank         float64 `json:"rank"`
+	Snippet      string  `json:"snippet"`
+
+	DisplayPrice *models.DisplayPrice `gorm:"-" json:"display_price,omitempty"`
+}
+
+// searchVisible limits search results to links shown on the public catalog.
+const searchVisible = `links.deleted_at IS NULL
+	AND links.is_active
+	AND (links.publish_at IS NULL OR links.publish_at <= ?)
+	AND (links.unpublish_at IS NULL OR links.unpublish_at > ?)`
+
+// SearchLinks ranks visible links by full-text match of the query against
+// their title, and with less weight their category name, as stored in the
+// indexed search_document.
+func SearchLinks(db *gorm.DB, query, headlineOptions string, limit int) ([]SearchHit, error) {
+	now := time.Now()
+
+	var hits []SearchHit
+	err := db.Raw(`
+		SELECT links.id, links.title, links.url, links.image_url, links.price, links.price_str,
+			links.category_id, categories.name AS category_name,
+			ts_rank(links.search_document, query) AS rank,
+			ts_headline('sekawan_search', links.title, query, ?) AS snippet
+		FROM links
+		JOIN categories ON categories.id = links.category_id AND categories.deleted_at IS NULL
+		CROSS JOIN websearch_to_tsquery('sekawan_search', ?) AS query
+		WHERE `+searchVisible+`
+			AND links.search_document @@ query
+		ORDER BY rank DESC, links.id
+		LIMIT ?`,
+		headlineOptions, query, now, now, limit,
+	).Scan(&hits).Error
+
+	return hits, err
+}
+
+// SearchLinksFuzzy ranks visible links by trigram similarity to the query,
+// for queries with typos that full-text search cannot match. Words that are
+// spelled correctly are still highlighted in the snippet.
+//
+// Candidates come from the trigram index on search_text through the <%
+// operator. A title's similarity never exceeds the query's word similarity to
+// the text that starts with that title, so minSimilarity as the operator's
+// threshold keeps every link the ranking accepts.
+func SearchLinksFuzzy(db *gorm.DB, query, headlineOptions string, minSimilarity float64, limit int) ([]SearchHit, error) {
+	now := time.Now()
+
+	var hits []SearchHit
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)`,
+			strconv.FormatFloat(minSimilarity, 'f', -1, 64)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Raw(`
+			SELECT id, title, url, image_url, price, price_str, category_id, category_name, rank,
+				ts_headline('sekawan_search', title, highlight, ?) AS snippet
+			FROM (
+				SELECT links.id, links.title, links.url, links.image_url, links.price, links.price_str,
+					links.category_id, categories.name AS category_name,
+					GREATEST(
+						similarity(sekawan_fold(links.title), sekawan_fold(?)),
+						word_similarity(sekawan_fold(?), links.search_text)
+					) AS rank
+				FROM links
+				JOIN categories ON categories.id = links.category_id AND categories.deleted_at IS NULL
+				WHERE `+searchVisible+`
+					AND sekawan_fold(?) <% links.search_text
+			) AS candidates
+			CROSS JOIN (
+				SELECT replace(plainto_tsquery('sekawan_search', ?)::text, '&', '|')::tsquery AS highlight
+			) AS highlights
+			WHERE rank >= ?
+			ORDER BY rank DESC, id
+			LIMIT ?`,
+			headlineOptions, query, query, now, now, query, query, minSimilarity, limit,
+		).Scan(&hits).Error
+	})
+
+	return hits, err
+}
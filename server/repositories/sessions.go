@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+func CreateSession(db *gorm.DB, session *models.Session) error {
+	return db.Create(session).Error
+}
+
+func GetSessionByID(db *gorm.DB, id uint) (*models.Session, error) {
+	var session models.Session
+	if err := db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func GetSessionByFamilyID(db *gorm.DB, familyID string) (*models.Session, error) {
+	var session models.Session
+	if err := db.Where("family_id = ?", familyID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func GetActiveSessions(db *gorm.DB, adminID uint) ([]models.Session, error) {
+	var sessions []models.Session
+	err := db.Where("admin_id = ? AND revoked_at IS NULL AND expires_at > ?", adminID, time.Now()).
+		Order("last_seen_at desc").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func TouchSession(db *gorm.DB, id uint, ip string, seenAt time.Time) error {
+	return db.Model(&models.Session{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"ip":           ip,
+		"last_seen_at": seenAt,
+	}).Error
+}
+
+func ExtendSession(db *gorm.DB, familyID string, expiresAt time.Time) error {
+	return db.Model(&models.Session{}).Where("family_id = ?", familyID).Updates(map[string]interface{}{
+		"last_seen_at": time.Now(),
+		"expires_at":   expiresAt,
+	}).Error
+}
+
+// RevokeSession ends a session and every refresh token issued to it.
+func RevokeSession(db *gorm.DB, familyID string) error {
+	err := db.Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return RevokeRefreshTokenFamily(db, familyID)
+}
+
+func RevokeAdminSessions(db *gorm.DB, adminID uint) error {
+	err := db.Model(&models.Session{}).
+		Where("admin_id = ? AND revoked_at IS NULL", adminID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return RevokeAdminRefreshTokens(db, adminID)
+}
+
+func DeleteExpiredSessions(db *gorm.DB) (int64, error) {
+	result := db.Where("expires_at <= ?", time.Now()).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
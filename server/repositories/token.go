@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"raya/models"
+)
+
+func CreateRevokedToken(db *gorm.DB, token *models.RevokedToken) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func GetActiveRevokedTokens(db *gorm.DB) ([]models.RevokedToken, error) {
+	var tokens []models.RevokedToken
+	err := db.Where("expires_at > ?", time.Now()).Find(&tokens).Error
+	return tokens, err
+}
+
+func DeleteExpiredRevokedTokens(db *gorm.DB) (int64, error) {
+	result := db.Where("expires_at <= ?", time.Now()).Delete(&models.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
+
+func CreateRefreshToken(db *gorm.DB, token *models.RefreshToken) error {
+	return db.Create(token).Error
+}
+
+func GetRefreshTokenByHash(db *gorm.DB, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed flags a token as rotated. It only succeeds for a token
+// that is still unused, so two concurrent refreshes cannot both win.
+func MarkRefreshTokenUsed(db *gorm.DB, id uint) (bool, error) {
+	result := db.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func RevokeRefreshTokenFamily(db *gorm.DB, familyID string) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func DeleteExpiredRefreshTokens(db *gorm.DB) (int64, error) {
+	result := db.Where("expires_at <= ?", time.Now()).Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+func UpdateTOTPSecret(db *gorm.DB, adminID uint, secret string) error {
+	return db.Model(&models.Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+		"totp_secret":    secret,
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}).Error
+}
+
+func EnableTOTP(db *gorm.DB, adminID uint, step int64) error {
+	return db.Model(&models.Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+		"totp_enabled":   true,
+		"totp_last_step": step,
+	}).Error
+}
+
+func DisableTOTP(db *gorm.DB, adminID uint) error {
+	if err := db.Where("admin_id = ?", adminID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return db.Model(&models.Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+		"totp_secret":    "",
+		"totp_enabled":   false,
+		"totp_last_step": 0,
+	}).Error
+}
+
+// UpdateTOTPLastStep records the step of an accepted code. It only succeeds
+// when the step is newer than the stored one, so a code cannot be replayed.
+func UpdateTOTPLastStep(db *gorm.DB, adminID uint, step int64) (bool, error) {
+	result := db.Model(&models.Admin{}).
+		Where("id = ? AND totp_last_step < ?", adminID, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+func ReplaceRecoveryCodes(db *gorm.DB, adminID uint, codes []models.RecoveryCode) error {
+	if err := db.Where("admin_id = ?", adminID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	return db.Create(&codes).Error
+}
+
+// UseRecoveryCode marks an unused code as used and reports whether one matched.
+func UseRecoveryCode(db *gorm.DB, adminID uint, codeHash string) (bool, error) {
+	result := db.Model(&models.RecoveryCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+)
+
+func GetTrashedLinks(db *gorm.DB) ([]models.Link, error) {
+	var links []models.Link
+	err := db.Unscoped().
+		Preload("Category", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at desc").
+		Find(&links).Error
+	return links, err
+}
+
+func GetTrashedCategories(db *gorm.DB) ([]models.Category, error) {
+	var categories []models.Category
+	err := db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at desc").
+		Find(&categories).Error
+	return categories, err
+}
+
+func GetTrashedLinkByID(db *gorm.DB, id uint) (*models.Link, error) {
+	var link models.Link
+	if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func GetTrashedCategoryByID(db *gorm.DB, id uint) (*models.Category, error) {
+	var category models.Category
+	if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetLinksTrashedWithCategory returns the links that were deleted together
+// with category.
+func GetLinksTrashedWithCategory(db *gorm.DB, category *models.Category) ([]models.Link, error) {
+	var links []models.Link
+	err := db.Unscoped().
+		Where("category_id = ? AND deleted_at = ?", category.ID, category.DeletedAt.Time).
+		Find(&links).Error
+	return links, err
+}
+
+// RestoreLink brings a link back at the end of its category, since its old
+// position may have been taken in the meantime. Its schedule state is reset
+// to the current window status so the scheduler leaves is_active alone.
+func RestoreLink(db *gorm.DB, link *models.Link) error {
+	if err := LockOrdering(db, link.CategoryID); err != nil {
+		return err
+	}
+	order, err := GetNextLinkOrder(db, link.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	return db.Unscoped().Model(&models.Link{}).Where("id = ?", link.ID).
+		Updates(map[string]interface{}{
+			"deleted_at":     nil,
+			"order":          order,
+			"schedule_state": link.WindowStatus(time.Now()),
+		}).Error
+}
+
+// RestoreCategory brings back a category, at the end of the list, and the
+// links that were deleted together with it.
+func RestoreCategory(db *gorm.DB, category *models.Category) error {
+	links, err := GetLinksTrashedWithCategory(db, category)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, link := range links {
+		err := db.Unscoped().Model(&models.Link{}).Where("id = ?", link.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "schedule_state": link.WindowStatus(now)}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := LockOrdering(db, 0); err != nil {
+		return err
+	}
+	order, err := GetNextCategoryOrder(db)
+	if err != nil {
+		return err
+	}
+
+	return db.Unscoped().Model(&models.Category{}).Where("id = ?", category.ID).
+		Updates(map[string]interface{}{"deleted_at": nil, "order": order}).Error
+}
+
+// PurgeTrash permanently deletes links and categories trashed before cutoff.
+// Purging a category cascades to its remaining links in the database.
+func PurgeTrash(db *gorm.DB, cutoff time.Time) (int64, int64, error) {
+	links := db.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Link{})
+	if links.Error != nil {
+		return 0, 0, links.Error
+	}
+
+	categories := db.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Category{})
+	if categories.Error != nil {
+		return links.RowsAffected, 0, categories.Error
+	}
+
+	return links.RowsAffected, categories.RowsAffected, nil
+}
@@ -3,6 +3,10 @@ package routes
 import (
 	"raya/controllers"
 	"raya/middleware"
+	"raya/models"
+	"log"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -15,6 +19,12 @@ import (
 func SetupRouter(db *gorm.DB) *gin.Engine {
 	r := gin.Default()
 
+	// ClientIP only honours X-Forwarded-For from the proxies listed in
+	// TRUSTED_PROXIES, so the login throttle can't be dodged by spoofing it.
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{
 			"https://sekawan-grup.com",
@@ -31,6 +41,7 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 			"X-CSRF-Token", 
 			"Authorization",
 			"Accept",
+			"X-API-Key",
 		},
 		ExposeHeaders:    []string{
 			"Content-Length", 
@@ -41,6 +52,7 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	}))
 
 	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET("/.well-known/jwks.json", controllers.GetJWKS)
 	
 	r.Use(func(c *gin.Context) {
 		if db == nil {
@@ -55,34 +67,106 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	r.Use(middleware.DetectMobileMiddleware())
 	{
 		api.GET("/categories-with-links", controllers.GetCategoriesWithLinks)//untuk section service
+		api.GET("/search", controllers.SearchLinks)
+		api.GET("/gold-prices", controllers.GetGoldPrices)
+		api.GET("/gold-prices/daily", controllers.GetDailyGoldPrices)
+		api.GET("/links/:id/price-history", controllers.GetLinkPriceHistory)
+		api.GET("/exchange-rates", controllers.GetExchangeRates)
 
 		// Auth
 		api.POST("/login", controllers.LoginUser)
+		api.POST("/login/2fa", controllers.VerifyTwoFactorLogin)
+		api.POST("/token/refresh", controllers.RefreshToken)
 
 		admin := api.Group("/")
 		admin.Use(middleware.AuthMiddleware())
 		{
-			admin.PATCH("/change-password", controllers.ChangePassword)
-			admin.POST("/logout", controllers.LogoutUser)
+			admin.PATCH("/change-password", middleware.RequireSession(), controllers.ChangePassword)
+			admin.POST("/logout", middleware.RequireSession(), controllers.LogoutUser)
+
+			// Sessions
+			admin.GET("/sessions", middleware.RequireSession(), controllers.GetSessions)
+			admin.DELETE("/sessions/:id", middleware.RequireSession(), controllers.RevokeSession)
+
+			// Two-factor authentication
+			admin.POST("/2fa/setup", middleware.RequireSession(), controllers.SetupTOTP)
+			admin.POST("/2fa/confirm", middleware.RequireSession(), controllers.ConfirmTOTP)
+			admin.POST("/2fa/disable", middleware.RequireSession(), controllers.DisableTOTP)
 
 			// Link management
-			admin.GET("/links/all", controllers.GetAllLinks)
-			admin.GET("/links/:id", controllers.GetLinkByID)
-			admin.GET("/links", controllers.GetLinks)//untuk dashboard/links
+			admin.GET("/links/all", middleware.RequirePermission(models.PermLinksRead), controllers.GetAllLinks)
+			admin.GET("/links/:id", middleware.RequirePermission(models.PermLinksRead), controllers.GetLinkByID)
+			admin.GET("/links", middleware.RequirePermission(models.PermLinksRead), controllers.GetLinks)//untuk dashboard/links
+			admin.GET("/links/:id/revisions", middleware.RequirePermission(models.PermLinksRead), controllers.GetLinkRevisions)
+			admin.GET("/links/:id/revisions/diff", middleware.RequirePermission(models.PermLinksRead), controllers.DiffLinkRevisions)
+			admin.POST("/links/:id/revisions/:number/rollback", middleware.RequirePermission(models.PermLinksWrite), controllers.RollbackLink)
+			admin.POST("/links/:id/move", middleware.RequirePermission(models.PermLinksWrite), controllers.MoveLink)
+			admin.POST("/links/:id/duplicate", middleware.RequirePermission(models.PermLinksWrite), controllers.DuplicateLink)
+			admin.POST("/import", middleware.RequirePermission(models.PermLinksWrite), middleware.RequirePermission(models.PermCategoriesWrite), controllers.ImportLinks)
 			
 			// Link management dalam kategori
-			admin.GET("/categories/:category_id/links", controllers.GetLinksByCategory)
-			admin.POST("/categories/:category_id/links", controllers.CreateLink)
-			admin.PATCH("/categories/:category_id/links/:link_id", controllers.UpdateLink)
-			admin.DELETE("/categories/:category_id/links/:link_id", controllers.DeleteLink)
+			admin.GET("/categories/:category_id/links", middleware.RequirePermission(models.PermLinksRead), controllers.GetLinksByCategory)
+			admin.POST("/categories/:category_id/links", middleware.RequirePermission(models.PermLinksWrite), controllers.CreateLink)
+			admin.PATCH("/categories/:category_id/links/:link_id", middleware.RequirePermission(models.PermLinksWrite), controllers.UpdateLink)
+			admin.DELETE("/categories/:category_id/links/:link_id", middleware.RequirePermission(models.PermLinksWrite), controllers.DeleteLink)
+			admin.PUT("/categories/:category_id/links/order", middleware.RequirePermission(models.PermLinksWrite), controllers.ReorderLinks)
 
 			// Category management
-			admin.GET("/categories", controllers.GetCategories)//untuk dashboard/categories
-			admin.GET("/category/:id", controllers.GetCategoryByID)
-			admin.POST("/category", controllers.CreateCategory)
-			admin.PATCH("/category/:id", controllers.UpdateCategory)
-			admin.DELETE("/category/:id", controllers.DeleteCategory)
+			admin.GET("/categories", middleware.RequirePermission(models.PermCategoriesRead), controllers.GetCategories)//untuk dashboard/categories
+			admin.PUT("/categories/order", middleware.RequirePermission(models.PermCategoriesWrite), controllers.ReorderCategories)
+			admin.GET("/category/:id", middleware.RequirePermission(models.PermCategoriesRead), controllers.GetCategoryByID)
+			admin.POST("/category", middleware.RequirePermission(models.PermCategoriesWrite), controllers.CreateCategory)
+			admin.PATCH("/category/:id", middleware.RequirePermission(models.PermCategoriesWrite), controllers.UpdateCategory)
+			admin.DELETE("/category/:id", middleware.RequirePermission(models.PermCategoriesWrite), controllers.DeleteCategory)
+			admin.GET("/category/:id/revisions", middleware.RequirePermission(models.PermCategoriesRead), controllers.GetCategoryRevisions)
+			admin.GET("/category/:id/revisions/diff", middleware.RequirePermission(models.PermCategoriesRead), controllers.DiffCategoryRevisions)
+			admin.POST("/category/:id/revisions/:number/rollback", middleware.RequirePermission(models.PermCategoriesWrite), controllers.RollbackCategory)
+
+			// Trash
+			admin.GET("/trash/links", middleware.RequirePermission(models.PermLinksRead), controllers.GetTrashedLinks)
+			admin.GET("/trash/categories", middleware.RequirePermission(models.PermCategoriesRead), controllers.GetTrashedCategories)
+			admin.POST("/trash/links/:id/restore", middleware.RequirePermission(models.PermLinksWrite), controllers.RestoreLink)
+			admin.POST("/trash/categories/:id/restore", middleware.RequirePermission(models.PermCategoriesWrite), controllers.RestoreCategory)
+
+			// Admin management
+			admin.GET("/admins", middleware.RequirePermission(models.PermAdminsManage), controllers.GetAdmins)
+			admin.GET("/admins/:id", middleware.RequirePermission(models.PermAdminsManage), controllers.GetAdminByID)
+			admin.POST("/admins", middleware.RequirePermission(models.PermAdminsManage), controllers.CreateAdmin)
+			admin.PATCH("/admins/:id", middleware.RequirePermission(models.PermAdminsManage), controllers.UpdateAdmin)
+			admin.DELETE("/admins/:id", middleware.RequirePermission(models.PermAdminsManage), controllers.DeleteAdmin)
+			admin.DELETE("/admins/:id/sessions", middleware.RequirePermission(models.PermAdminsManage), controllers.RevokeAdminSessions)
+
+			// Gold prices
+			admin.GET("/gold-prices/history", middleware.RequirePermission(models.PermLinksRead), controllers.GetGoldPriceHistory)
+			admin.POST("/gold-prices", middleware.RequirePermission(models.PermLinksWrite), controllers.RecordGoldPrices)
+
+			// Exchange rates
+			admin.POST("/exchange-rates", middleware.RequirePermission(models.PermLinksWrite), controllers.RecordExchangeRates)
+
+			// Backup
+			admin.GET("/export", middleware.RequirePermission(models.PermLinksRead), middleware.RequirePermission(models.PermCategoriesRead), controllers.ExportCatalog)
+			admin.POST("/restore", middleware.RequirePermission(models.PermLinksWrite), middleware.RequirePermission(models.PermCategoriesWrite), controllers.RestoreCatalog)
+
+			// Audit log
+			admin.GET("/audit", middleware.RequirePermission(models.PermAuditRead), controllers.GetAuditLogs)
+
+			// API key management
+			admin.GET("/api-keys", middleware.RequireSession(), middleware.RequirePermission(models.PermAPIKeysManage), controllers.GetAPIKeys)
+			admin.POST("/api-keys", middleware.RequireSession(), middleware.RequirePermission(models.PermAPIKeysManage), controllers.CreateAPIKey)
+			admin.DELETE("/api-keys/:id", middleware.RequireSession(), middleware.RequirePermission(models.PermAPIKeysManage), controllers.RevokeAPIKey)
 		}
 	}
 	return r
-}
\ No newline at end of file
+}
+
+// trustedProxies reads the comma-separated TRUSTED_PROXIES list. None are
+// trusted when it is unset.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+	"raya/utils"
+)
+
+var ErrLastOwner = errors.New("harus ada minimal satu owner")
+
+func GetAdmins(db *gorm.DB) ([]models.Admin, error) {
+	return repositories.GetAdmins(db)
+}
+
+func GetAdminByID(db *gorm.DB, id uint) (*models.Admin, error) {
+	return repositories.GetUserByID(db, id)
+}
+
+func CreateAdmin(db *gorm.DB, username, password, role string) (*models.Admin, error) {
+	if username == "" || len(password) < 6 {
+		return nil, errors.New("username dan password (minimal 6 karakter) wajib diisi")
+	}
+	if !models.IsValidRole(role) {
+		return nil, errors.New("role tidak valid")
+	}
+
+	if _, err := repositories.GetUserByUsername(db, username); err == nil {
+		return nil, errors.New("username sudah digunakan")
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, errors.New("gagal membuat password")
+	}
+
+	admin := models.Admin{
+		Username:           username,
+		Password:           hashedPassword,
+		Role:               role,
+		MustChangePassword: true,
+	}
+	if err := repositories.CreateAdmin(db, &admin); err != nil {
+		return nil, err
+	}
+
+	return &admin, nil
+}
+
+// UpdateAdmin changes an admin's role and, if given, resets their password,
+// which they must then change on next login. Existing sessions of that admin
+// are revoked so the change applies at once.
+func UpdateAdmin(db *gorm.DB, id uint, role, password string) (*models.Admin, error) {
+	admin, err := repositories.GetUserByID(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role != "" && role != admin.Role {
+		if !models.IsValidRole(role) {
+			return nil, errors.New("role tidak valid")
+		}
+		if admin.Role == models.RoleOwner {
+			if err := ensureAnotherOwner(db); err != nil {
+				return nil, err
+			}
+		}
+		admin.Role = role
+	}
+
+	if password != "" {
+		if len(password) < 6 {
+			return nil, errors.New("password minimal 6 karakter")
+		}
+		hashedPassword, err := utils.HashPassword(password)
+		if err != nil {
+			return nil, errors.New("gagal membuat password baru")
+		}
+		admin.Password = hashedPassword
+		admin.MustChangePassword = true
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.UpdateAdmin(tx, admin); err != nil {
+			return err
+		}
+		return repositories.RevokeAdminSessions(tx, admin.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+func DeleteAdmin(db *gorm.DB, id, currentAdminID uint) error {
+	if id == currentAdminID {
+		return errors.New("tidak dapat menghapus akun sendiri")
+	}
+
+	admin, err := repositories.GetUserByID(db, id)
+	if err != nil {
+		return err
+	}
+
+	if admin.Role == models.RoleOwner {
+		if err := ensureAnotherOwner(db); err != nil {
+			return err
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.RevokeAdminSessions(tx, id); err != nil {
+			return err
+		}
+		return repositories.DeleteAdmin(tx, id)
+	})
+}
+
+func ensureAnotherOwner(db *gorm.DB) error {
+	owners, err := repositories.CountAdminsByRole(db, models.RoleOwner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+	"raya/utils"
+)
+
+const (
+	apiKeyPrefix = "sek_"
+	// apiKeyTouchInterval limits how often last_used_at is written for a
+	// busy key.
+	apiKeyTouchInterval = time.Minute
+)
+
+var ErrInvalidAPIKey = errors.New("API key tidak valid")
+
+// CreatedAPIKey is returned once on creation; Key is never shown again.
+type CreatedAPIKey struct {
+	models.APIKey
+	Key string `json:"key"`
+}
+
+func GetAPIKeys(db *gorm.DB) ([]models.APIKey, error) {
+	return repositories.GetAPIKeys(db)
+}
+
+func CreateAPIKey(db *gorm.DB, creator *models.Admin, name string, scopes []string, expiresAt *time.Time) (*CreatedAPIKey, error) {
+	if name == "" || len(scopes) == 0 {
+		return nil, errors.New("nama dan scope API key wajib diisi")
+	}
+	for _, scope := range scopes {
+		if !models.IsValidAPIKeyScope(scope) {
+			return nil, errors.New("scope tidak valid: " + scope)
+		}
+		if !models.RoleHasPermission(creator.Role, scope) {
+			return nil, errors.New("tidak dapat memberikan scope yang tidak Anda miliki: " + scope)
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, errors.New("masa berlaku harus di masa depan")
+	}
+
+	secret, err := utils.GenerateRandomString(24)
+	if err != nil {
+		return nil, err
+	}
+	raw := apiKeyPrefix + secret
+
+	key := models.APIKey{
+		Name:        name,
+		Prefix:      raw[:len(apiKeyPrefix)+8],
+		KeyHash:     utils.HashToken(raw),
+		Scopes:      scopes,
+		CreatedByID: creator.ID,
+		ExpiresAt:   expiresAt,
+	}
+	if err := repositories.CreateAPIKey(db, &key); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func RevokeAPIKey(db *gorm.DB, id uint) error {
+	return repositories.RevokeAPIKey(db, id)
+}
+
+// AuthenticateAPIKey resolves a raw key to its record and the admin who
+// created it. Keys stop working when revoked, expired or when their creator
+// is deleted.
+func AuthenticateAPIKey(db *gorm.DB, raw string) (*models.APIKey, *models.Admin, error) {
+	key, err := repositories.GetAPIKeyByHash(db, utils.HashToken(raw))
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	creator, err := repositories.GetUserByID(db, key.CreatedByID)
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := repositories.TouchAPIKey(db, key.ID, now); err != nil {
+			return nil, nil, err
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, creator, nil
+}
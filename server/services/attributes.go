@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+const maxSKULength = 64
+
+var ErrSKUTaken = errors.New("SKU is already used by another link")
+
+// validateGoldAttributes checks the gold attributes of a link and trims its
+// free-text ones. Attributes left at their zero value are not set.
+func validateGoldAttributes(link *models.Link) error {
+	link.Brand = strings.TrimSpace(link.Brand)
+	link.SKU = strings.TrimSpace(link.SKU)
+
+	if link.WeightGrams < 0 {
+		return errors.New("weight_grams must not be negative")
+	}
+	if link.Karat < 0 || link.Karat > models.MaxKarat {
+		return fmt.Errorf("karat must be between 0 and %d, 0 for not set", models.MaxKarat)
+	}
+	if link.ProductType != "" && !models.IsValidProductType(link.ProductType) {
+		return fmt.Errorf("product_type must be one of %s", strings.Join(models.ProductTypes, ", "))
+	}
+	if len(link.SKU) > maxSKULength {
+		return fmt.Errorf("sku must be at most %d characters", maxSKULength)
+	}
+	if link.MakingFee < 0 {
+		return errors.New("making_fee must not be negative")
+	}
+	if link.AutoPrice && (link.WeightGrams <= 0 || link.Karat == 0) {
+		return errors.New("auto_price needs weight_grams and karat")
+	}
+	return nil
+}
+
+// checkSKUAvailable rejects a SKU that another live link already uses.
+func checkSKUAvailable(tx *gorm.DB, sku string, linkID uint) error {
+	if sku == "" {
+		return nil
+	}
+
+	existing, err := repositories.GetLinkBySKU(tx, sku)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != linkID {
+		return ErrSKUTaken
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+// Actor identifies who performed a mutation, for the audit log.
+type Actor struct {
+	AdminID   uint
+	Username  string
+	APIKeyID  *uint
+	IP        string
+	UserAgent string
+}
+
+type AuditPage = Page[models.AuditLog]
+
+func GetAuditLogs(db *gorm.DB, filter repositories.AuditFilter, page, perPage int) (*AuditPage, error) {
+	page, perPage = normalizePage(page, perPage)
+	filter.Offset = (page - 1) * perPage
+	filter.Limit = perPage
+
+	logs, total, err := repositories.GetAuditLogs(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(logs, total, page, perPage), nil
+}
+
+// recordAudit appends an audit entry. before is nil for creates and after is
+// nil for deletes. It should run in the same transaction as the mutation.
+func recordAudit(tx *gorm.DB, actor Actor, action, entityType string, entityID uint, before, after interface{}) error {
+	beforeJSON, beforeMap, err := snapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, afterMap, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	diff, err := json.Marshal(diffSnapshots(beforeMap, afterMap))
+	if err != nil {
+		return err
+	}
+
+	entry := models.AuditLog{
+		ActorID:       actor.AdminID,
+		ActorUsername: actor.Username,
+		APIKeyID:      actor.APIKeyID,
+		Action:        action,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Before:        beforeJSON,
+		After:         afterJSON,
+		Diff:          diff,
+		IP:            actor.IP,
+		UserAgent:     actor.UserAgent,
+	}
+	return repositories.CreateAuditLog(tx, &entry)
+}
+
+func snapshot(entity interface{}) (models.JSON, map[string]interface{}, error) {
+	if entity == nil || reflect.ValueOf(entity).IsNil() {
+		return nil, nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, err
+	}
+	return data, fields, nil
+}
+
+type fieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// diffSnapshots lists the fields whose values differ between two snapshots.
+func diffSnapshots(before, after map[string]interface{}) map[string]fieldChange {
+	diff := map[string]fieldChange{}
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			diff[key] = fieldChange{From: before[key], To: value}
+		}
+	}
+	for key, old := range before {
+		if _, ok := after[key]; !ok {
+			diff[key] = fieldChange{From: old, To: nil}
+		}
+	}
+	return diff
+}
@@ -2,28 +2,235 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"sync"
+	"time"
+
 	"gorm.io/gorm"
 
+	"raya/models"
 	"raya/repositories"
 	"raya/utils"
 )
 
-func LoginUser(db *gorm.DB, username, password string) (string, error) {
+var (
+	ErrInvalidCredentials = errors.New("username atau password salah")
+	ErrRefreshTokenReused = errors.New("refresh token sudah digunakan")
+)
+
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// LoginResult holds either a token pair or, for admins with TOTP enabled, a
+// challenge token to exchange at /login/2fa.
+type LoginResult struct {
+	*TokenPair
+	MustChangePassword bool   `json:"must_change_password"`
+	TwoFactorRequired  bool   `json:"two_factor_required"`
+	ChallengeToken     string `json:"challenge_token,omitempty"`
+}
+
+// LoginUser checks credentials and issues a token pair. Unknown usernames and
+// wrong passwords fail the same way and take the same time, and repeated
+// failures from one IP or against one username are throttled.
+func LoginUser(db *gorm.DB, username, password string, client Client) (*LoginResult, error) {
+	ipKey, userKey := "ip:"+client.IP, "user:"+username
+	if err := checkLoginThrottle(ipKey, userKey); err != nil {
+		return nil, err
+	}
+
 	user, err := repositories.GetUserByUsername(db, username)
 	if err != nil {
-		return "", errors.New("user tidak ditemukan")
+		utils.CheckPassword(password, dummyPasswordHash())
+		recordLoginFailure(ipKey, userKey)
+		return nil, ErrInvalidCredentials
+	}
+
+	// A locked account answers like an unknown one so the lock doesn't
+	// reveal that the username exists.
+	if user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		utils.CheckPassword(password, user.Password)
+		recordLoginFailure(ipKey, userKey)
+		return nil, ErrInvalidCredentials
 	}
 
 	if !utils.CheckPassword(password, user.Password) {
-		return "", errors.New("password salah")
+		recordLoginFailure(ipKey, userKey)
+		if err := recordAccountFailure(db, user); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	resetLoginFailures(userKey)
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := repositories.ResetFailedLogins(db, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.TOTPEnabled {
+		challenge, err := utils.GenerateChallengeJWT(*user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	pair, err := startSession(db, user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{TokenPair: pair, MustChangePassword: user.MustChangePassword}, nil
+}
+
+// startSession records a new session for the client and issues the first
+// token pair of its refresh token family.
+func startSession(db *gorm.DB, user *models.Admin, client Client) (*TokenPair, error) {
+	familyID, err := utils.GenerateRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	var pair *TokenPair
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		session := models.Session{
+			AdminID:    user.ID,
+			FamilyID:   familyID,
+			Device:     utils.DescribeDevice(client.UserAgent),
+			UserAgent:  client.UserAgent,
+			IP:         client.IP,
+			LastSeenAt: now,
+			ExpiresAt:  now.Add(utils.RefreshTokenTTL),
+		}
+		if err := repositories.CreateSession(tx, &session); err != nil {
+			return err
+		}
+
+		pair, err = issueTokenPair(tx, user, familyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// recordAccountFailure persists a failed attempt on the admin and locks the
+// account once MaxFailedLogins is reached.
+func recordAccountFailure(db *gorm.DB, user *models.Admin) error {
+	failedLogins := user.FailedLogins + 1
+	if user.LockedUntil != nil || (user.LastFailedAt != nil && time.Since(*user.LastFailedAt) > LockoutDuration) {
+		// A previous lockout has expired or the old failures are stale.
+		failedLogins = 1
+	}
+
+	var lockedUntil *time.Time
+	if failedLogins >= MaxFailedLogins {
+		until := time.Now().Add(LockoutDuration)
+		lockedUntil = &until
+	}
+
+	return repositories.RecordFailedLogin(db, user.ID, failedLogins, lockedUntil)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// dummyPasswordHash is compared against when the username does not exist so
+// that the request costs the same bcrypt work as a real one.
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = utils.HashPassword("sekawan-dummy-password")
+	})
+	return dummyHash
+}
+
+// RefreshTokens rotates a refresh token. Presenting a token that was already
+// rotated means it leaked, so the whole family and its session are revoked.
+func RefreshTokens(db *gorm.DB, refreshToken string) (*TokenPair, error) {
+	stored, err := repositories.GetRefreshTokenByHash(db, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, errors.New("refresh token tidak valid")
 	}
 
-	token, err := utils.GenerateJWT(*user)
+	if stored.UsedAt != nil || stored.RevokedAt != nil {
+		if err := repositories.RevokeSession(db, stored.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, errors.New("refresh token kedaluwarsa")
+	}
+
+	var pair *TokenPair
+	err = db.Transaction(func(tx *gorm.DB) error {
+		ok, err := repositories.MarkRefreshTokenUsed(tx, stored.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrRefreshTokenReused
+		}
+
+		user, err := repositories.GetUserByID(tx, stored.AdminID)
+		if err != nil {
+			return errors.New("admin tidak ditemukan")
+		}
+
+		pair, err = issueTokenPair(tx, user, stored.FamilyID)
+		if err != nil {
+			return err
+		}
+		return repositories.ExtendSession(tx, stored.FamilyID, time.Now().Add(utils.RefreshTokenTTL))
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if err := repositories.RevokeSession(db, stored.FamilyID); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	return pair, nil
+}
+
+func issueTokenPair(db *gorm.DB, user *models.Admin, familyID string) (*TokenPair, error) {
+	accessToken, err := utils.GenerateJWT(*user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := models.RefreshToken{
+		AdminID:   user.ID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	}
+	if err := repositories.CreateRefreshToken(db, &stored); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
 func UpdatePassword(db *gorm.DB, userID uint, currentPassword, newPassword string) error {
@@ -36,6 +243,13 @@ func UpdatePassword(db *gorm.DB, userID uint, currentPassword, newPassword strin
 		return errors.New("password saat ini salah")
 	}
 
+	if currentPassword == newPassword {
+		return errors.New("password baru harus berbeda dari password saat ini")
+	}
+	if len(newPassword) < utils.MinPasswordLength {
+		return fmt.Errorf("password baru minimal %d karakter", utils.MinPasswordLength)
+	}
+
 	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
 		return errors.New("gagal membuat password baru")
@@ -44,6 +258,17 @@ func UpdatePassword(db *gorm.DB, userID uint, currentPassword, newPassword strin
 	return repositories.UpdatePassword(db, userID, hashedPassword)
 }
 
-func LogoutUser(token string) error {
-	return nil
+// LogoutUser revokes the access token and ends the session it was issued to.
+func LogoutUser(db *gorm.DB, jti, sessionID string, adminID uint, expiresAt time.Time) error {
+	if jti == "" {
+		return errors.New("token tidak valid")
+	}
+
+	if sessionID != "" {
+		if err := repositories.RevokeSession(db, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return RevokeToken(db, jti, adminID, expiresAt)
 }
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+	"raya/utils"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("mata uang tidak didukung")
+	ErrNoExchangeRate  = errors.New("kurs untuk mata uang ini belum tersedia")
+	ErrInvalidRate     = errors.New("kurs tidak valid")
+)
+
+// RateSource supplies exchange rates to RecordExchangeRates.
+type RateSource interface {
+	// Name is stored with each rate to tell where it came from.
+	Name() string
+	Fetch(ctx context.Context) ([]models.ExchangeRate, error)
+}
+
+// RateFeed is the JSON document read from a rate source and accepted for
+// manual entry. Rates hold the rupiah price of one unit of each currency,
+// such as {"MYR": 3520.5}, and share rated_at, which defaults to the time the
+// document is read.
+type RateFeed struct {
+	RatedAt *time.Time         `json:"rated_at"`
+	Rates   map[string]float64 `json:"rates" binding:"required"`
+}
+
+func (feed *RateFeed) ExchangeRates(now time.Time) []models.ExchangeRate {
+	ratedAt := now
+	if feed.RatedAt != nil {
+		ratedAt = *feed.RatedAt
+	}
+
+	rates := make([]models.ExchangeRate, 0, len(feed.Rates))
+	for currency, rate := range feed.Rates {
+		rates = append(rates, models.ExchangeRate{Currency: strings.ToUpper(currency), IDRRate: rate, RatedAt: ratedAt})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Currency < rates[j].Currency })
+	return rates
+}
+
+// HTTPRateSource reads a RateFeed document from URL.
+type HTTPRateSource struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s *HTTPRateSource) Name() string {
+	return feedSourceName(s.URL)
+}
+
+func (s *HTTPRateSource) Fetch(ctx context.Context) ([]models.ExchangeRate, error) {
+	var feed RateFeed
+	if err := fetchFeed(ctx, s.Client, s.URL, &feed); err != nil {
+		return nil, err
+	}
+	return feed.ExchangeRates(time.Now()), nil
+}
+
+// FileRateSource reads a RateFeed document from a local file, standing in
+// for a live source in development.
+type FileRateSource struct {
+	Path string
+}
+
+func (s *FileRateSource) Name() string {
+	return "file"
+}
+
+func (s *FileRateSource) Fetch(ctx context.Context) ([]models.ExchangeRate, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var feed RateFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", s.Path, err)
+	}
+	return feed.ExchangeRates(time.Now()), nil
+}
+
+// ManualRateSource carries the rates an admin entered by hand.
+type ManualRateSource struct {
+	Feed RateFeed
+}
+
+func (s *ManualRateSource) Name() string {
+	return "manual"
+}
+
+func (s *ManualRateSource) Fetch(ctx context.Context) ([]models.ExchangeRate, error) {
+	return s.Feed.ExchangeRates(time.Now()), nil
+}
+
+// RateUpdate reports what RecordExchangeRates did. Skipped counts rates that
+// were not newer than the stored rate of their currency.
+type RateUpdate struct {
+	Source  string                `json:"source"`
+	Rates   []models.ExchangeRate `json:"rates"`
+	Skipped int                   `json:"skipped"`
+}
+
+// RecordExchangeRates fetches rates from source and stores the ones that are
+// newer than what is known for their currency.
+func RecordExchangeRates(db *gorm.DB, source RateSource) (*RateUpdate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), feedFetchTimeout)
+	defer cancel()
+
+	rates, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRates(rates, time.Now()); err != nil {
+		return nil, err
+	}
+
+	update := &RateUpdate{Source: source.Name(), Rates: []models.ExchangeRate{}}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i := range rates {
+			rate := rates[i]
+			rate.Source = update.Source
+
+			latest, err := repositories.GetLatestExchangeRate(tx, rate.Currency)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if latest != nil && !rate.RatedAt.After(latest.RatedAt) {
+				update.Skipped++
+				continue
+			}
+
+			if err := repositories.CreateExchangeRate(tx, &rate); err != nil {
+				return err
+			}
+			update.Rates = append(update.Rates, rate)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
+
+func validateRates(rates []models.ExchangeRate, now time.Time) error {
+	if len(rates) == 0 {
+		return fmt.Errorf("%w: tidak ada kurs", ErrInvalidRate)
+	}
+
+	for _, rate := range rates {
+		if rate.Currency == utils.CurrencyIDR || !utils.IsSupportedCurrency(rate.Currency) {
+			return fmt.Errorf("%w: mata uang %s tidak didukung", ErrInvalidRate, rate.Currency)
+		}
+		if !(rate.IDRRate > 0) || math.IsInf(rate.IDRRate, 0) {
+			return fmt.Errorf("%w: kurs %s harus lebih dari 0", ErrInvalidRate, rate.Currency)
+		}
+		if rate.RatedAt.After(now.Add(maxClockSkew)) {
+			return fmt.Errorf("%w: waktu kurs %s ada di masa depan", ErrInvalidRate, rate.Currency)
+		}
+	}
+	return nil
+}
+
+func GetLatestExchangeRates(db *gorm.DB) ([]models.ExchangeRate, error) {
+	return repositories.GetLatestExchangeRates(db)
+}
+
+// currencyConverter turns rupiah prices into display prices at one rate.
+type currencyConverter struct {
+	rate models.ExchangeRate
+}
+
+// converterFor returns the converter for a currency asked for on the public
+// catalog, or nil when prices should stay in rupiah only.
+func converterFor(db *gorm.DB, currency string) (*currencyConverter, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == utils.CurrencyIDR {
+		return nil, nil
+	}
+	if !utils.IsSupportedCurrency(currency) {
+		return nil, ErrUnknownCurrency
+	}
+
+	rate, err := repositories.GetLatestExchangeRate(db, currency)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoExchangeRate
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &currencyConverter{rate: *rate}, nil
+}
+
+func (c *currencyConverter) convert(amount int64) float64 {
+	return utils.RoundMoney(c.rate.Currency, float64(amount)/c.rate.IDRRate)
+}
+
+// displayPrice converts a price, and the sale price of a promo when there is
+// one.
+func (c *currencyConverter) displayPrice(price int64, promo *models.LinkPromo) *models.DisplayPrice {
+	display := &models.DisplayPrice{
+		Currency: c.rate.Currency,
+		Price:    c.convert(price),
+		Rate:     c.rate.IDRRate,
+		RatedAt:  c.rate.RatedAt,
+	}
+	display.PriceStr = utils.FormatMoney(display.Currency, display.Price)
+
+	if promo != nil {
+		sale := c.convert(promo.SalePrice)
+		display.SalePrice = &sale
+		display.SalePriceStr = utils.FormatMoney(display.Currency, sale)
+	}
+	return display
+}
+
+func (c *currencyConverter) convertLinks(links []models.Link) {
+	for i := range links {
+		links[i].DisplayPrice = c.displayPrice(links[i].Price, links[i].Promo)
+	}
+}
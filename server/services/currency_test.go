@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"raya/models"
+)
+
+func TestFileRateSourceFetch(t *testing.T) {
+	source := &FileRateSource{Path: "../database/fixtures/exchange_rates.json"}
+	rates, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	ratedAt := time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+	want := []models.ExchangeRate{
+		{Currency: "MYR", IDRRate: 3520.5, RatedAt: ratedAt},
+		{Currency: "SGD", IDRRate: 12110.25, RatedAt: ratedAt},
+	}
+	if len(rates) != len(want) {
+		t.Fatalf("got %d rates, want %d", len(rates), len(want))
+	}
+	for i, rate := range rates {
+		if rate.Currency != want[i].Currency || rate.IDRRate != want[i].IDRRate || !rate.RatedAt.Equal(want[i].RatedAt) {
+			t.Errorf("rate %d = %+v, want %+v", i, rate, want[i])
+		}
+	}
+	if err := validateRates(rates, ratedAt); err != nil {
+		t.Errorf("validateRates: %v", err)
+	}
+}
+
+func TestFileRateSourceFetchMissingFile(t *testing.T) {
+	source := &FileRateSource{Path: "../database/fixtures/missing.json"}
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Fatal("Fetch succeeded on a missing file")
+	}
+}
+
+func TestValidateRatesRejectsFutureRates(t *testing.T) {
+	now := time.Now()
+	rates := []models.ExchangeRate{{Currency: "SGD", IDRRate: 12110.25, RatedAt: now.Add(time.Hour)}}
+	if err := validateRates(rates, now); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("error = %v, want ErrInvalidRate", err)
+	}
+
+	rates[0].RatedAt = now
+	if err := validateRates(rates, now); err != nil {
+		t.Errorf("current rate rejected: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+// ExportVersion is bumped whenever the export document changes in a way
+// RestoreCatalog has to know about.
+const ExportVersion = 1
+
+var (
+	ErrExportVersion   = errors.New("versi dokumen export tidak didukung")
+	ErrCatalogNotEmpty = errors.New("restore hanya dapat dilakukan pada database yang belum berisi kategori atau link")
+	ErrRestoreInvalid  = errors.New("dokumen export tidak valid")
+)
+
+// exportSnapshot runs an export on one consistent view of the catalog.
+var exportSnapshot = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// ExportDocument is the layout written by ExportCatalog and read by
+// RestoreCatalog.
+type ExportDocument struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Categories []models.Category `json:"categories"`
+	Links      []models.Link     `json:"links"`
+}
+
+// ExportCatalog writes every category and link to w as an ExportDocument.
+// Rows are written as they are read so the catalog is never held in memory
+// as a whole.
+func ExportCatalog(db *gorm.DB, w io.Writer) error {
+	// Categories and links are read from one snapshot, so a link never
+	// refers to a category that is missing from the document.
+	return db.Transaction(func(tx *gorm.DB) error {
+		exportedAt, err := json.Marshal(time.Now())
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, `{"version":%d,"exported_at":%s,"categories":[`, ExportVersion, exportedAt); err != nil {
+			return err
+		}
+
+		categories := &jsonArrayWriter{w: w}
+		err = repositories.EachCategory(tx, func(category *models.Category) error {
+			return categories.write(category)
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, `],"links":[`); err != nil {
+			return err
+		}
+
+		links := &jsonArrayWriter{w: w}
+		err = repositories.EachLink(tx, func(link *models.Link) error {
+			return links.write(link)
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(w, "]}")
+		return err
+	}, exportSnapshot)
+}
+
+type jsonArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (a *jsonArrayWriter) write(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if a.count > 0 {
+		if _, err := io.WriteString(a.w, ","); err != nil {
+			return err
+		}
+	}
+	a.count++
+	_, err = a.w.Write(data)
+	return err
+}
+
+func ExportCategoriesCSV(db *gorm.DB, w io.Writer) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"id", "name", "order"}); err != nil {
+			return err
+		}
+
+		err := repositories.EachCategory(tx, func(category *models.Category) error {
+			return writer.Write([]string{
+				strconv.FormatUint(uint64(category.ID), 10),
+				category.Name,
+				strconv.Itoa(category.Order),
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		writer.Flush()
+		return writer.Error()
+	}, exportSnapshot)
+}
+
+// ExportLinksCSV writes one row per link. The category column holds the
+// category name, so the file can be fed back to ImportLinks.
+func ExportLinksCSV(db *gorm.DB, w io.Writer) error {
+	// Names and links come from one snapshot so every name matches.
+	return db.Transaction(func(tx *gorm.DB) error {
+		categories, err := repositories.GetCategories(tx)
+		if err != nil {
+			return err
+		}
+		names := make(map[uint]string, len(categories))
+		for _, category := range categories {
+			names[category.ID] = category.Name
+		}
+
+		writer := csv.NewWriter(w)
+		header := []string{"id", "category_id", "category", "order", "title", "url", "image_url", "price", "price_str", "is_active", "publish_at", "unpublish_at", "weight_grams", "karat", "product_type", "brand", "sku"}
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+
+		err = repositories.EachLink(tx, func(link *models.Link) error {
+			return writer.Write([]string{
+				strconv.FormatUint(uint64(link.ID), 10),
+				strconv.FormatUint(uint64(link.CategoryID), 10),
+				names[link.CategoryID],
+				strconv.Itoa(link.Order),
+				link.Title,
+				link.URL,
+				link.ImageURL,
+				strconv.FormatInt(link.Price, 10),
+				link.PriceStr,
+				strconv.FormatBool(link.IsActive),
+				formatCSVTime(link.PublishAt),
+				formatCSVTime(link.UnpublishAt),
+				strconv.FormatFloat(link.WeightGrams, 'f', -1, 64),
+				strconv.Itoa(link.Karat),
+				link.ProductType,
+				link.Brand,
+				link.SKU,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		writer.Flush()
+		return writer.Error()
+	}, exportSnapshot)
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// RestoreCatalog loads an export document into a database that has no
+// categories or links yet. Rows get new IDs; links follow their category and
+// both keep their relative order.
+func RestoreCatalog(db *gorm.DB, document *ExportDocument, actor Actor) (int, int, error) {
+	if document.Version != ExportVersion {
+		return 0, 0, ErrExportVersion
+	}
+
+	categories := append([]models.Category(nil), document.Categories...)
+	sort.SliceStable(categories, func(i, j int) bool { return categories[i].Order < categories[j].Order })
+	links := append([]models.Link(nil), document.Links...)
+	sort.SliceStable(links, func(i, j int) bool { return links[i].Order < links[j].Order })
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		count, err := repositories.CountCatalog(tx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrCatalogNotEmpty
+		}
+
+		categoryIDs := make(map[uint]uint, len(categories))
+		for i, source := range categories {
+			category := models.Category{Name: source.Name, Order: i + 1}
+			if err := validateCategory(&category); err != nil {
+				return fmt.Errorf("%w: kategori %d: %v", ErrRestoreInvalid, source.ID, err)
+			}
+			if err := CreateCategory(tx, &category, actor); err != nil {
+				return err
+			}
+			categoryIDs[source.ID] = category.ID
+		}
+
+		positions := map[uint]int{}
+		for i := range links {
+			categoryID, ok := categoryIDs[links[i].CategoryID]
+			if !ok {
+				return fmt.Errorf("%w: link %d merujuk ke kategori %d yang tidak ada", ErrRestoreInvalid, links[i].ID, links[i].CategoryID)
+			}
+			positions[categoryID]++
+
+			link := *linkSnapshot(&links[i])
+			link.ID = 0
+			link.CategoryID = categoryID
+			link.Order = positions[categoryID]
+			link.DeletedAt = gorm.DeletedAt{}
+			if err := validateLink(&link); err != nil {
+				return fmt.Errorf("%w: link %d: %v", ErrRestoreInvalid, links[i].ID, err)
+			}
+			if err := validatePromo(&link); err != nil {
+				return fmt.Errorf("%w: link %d: %v", ErrRestoreInvalid, links[i].ID, err)
+			}
+			if err := CreateLink(tx, &link, actor); err != nil {
+				// Only a SKU repeated within the document can be taken
+				// in an empty catalog.
+				if errors.Is(err, ErrSKUTaken) {
+					return fmt.Errorf("%w: link %d: %v", ErrRestoreInvalid, links[i].ID, err)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(categories), len(links), nil
+}
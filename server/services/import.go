@@ -0,0 +1,445 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionError  = "error"
+)
+
+var (
+	ErrImportInvalid    = errors.New("import berisi baris yang tidak valid, tidak ada data yang disimpan")
+	ErrImportNoURLField = errors.New("kolom url wajib ada di baris judul CSV")
+)
+
+// ImportRow is one link read from an import file. Nil fields were not in the
+// file and keep their current value when the link already exists.
+type ImportRow struct {
+	Line     int     `json:"-"`
+	Title    *string `json:"title"`
+	URL      string  `json:"url"`
+	ImageURL *string `json:"image_url"`
+	Price    *int64  `json:"price"`
+	PriceStr *string `json:"price_str"`
+	Category string  `json:"category"`
+	IsActive *bool   `json:"is_active"`
+
+	WeightGrams *float64 `json:"weight_grams"`
+	Karat       *int     `json:"karat"`
+	ProductType *string  `json:"product_type"`
+	Brand       *string  `json:"brand"`
+	SKU         *string  `json:"sku"`
+
+	problems []string
+}
+
+type ImportRowResult struct {
+	Line     int      `json:"line"`
+	Action   string   `json:"action"`
+	LinkID   uint     `json:"link_id,omitempty"`
+	Title    string   `json:"title"`
+	URL      string   `json:"url"`
+	Category string   `json:"category"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun            bool              `json:"dry_run"`
+	Created           int               `json:"created"`
+	Updated           int               `json:"updated"`
+	Failed            int               `json:"failed"`
+	CategoriesCreated []string          `json:"categories_created"`
+	Rows              []ImportRowResult `json:"rows"`
+}
+
+// importColumns maps the accepted CSV column names, in English or
+// Indonesian, to the link field they fill.
+var importColumns = map[string]string{
+	"title":     "title",
+	"judul":     "title",
+	"nama":      "title",
+	"url":       "url",
+	"link":      "url",
+	"image_url": "image_url",
+	"image":     "image_url",
+	"gambar":    "image_url",
+	"price":     "price",
+	"harga":     "price",
+	"price_str": "price_str",
+	"category":  "category",
+	"kategori":  "category",
+	"is_active": "is_active",
+	"aktif":     "is_active",
+
+	"weight_grams": "weight_grams",
+	"berat":        "weight_grams",
+	"karat":        "karat",
+	"kadar":        "karat",
+	"product_type": "product_type",
+	"jenis":        "product_type",
+	"brand":        "brand",
+	"merek":        "brand",
+	"toko":         "brand",
+	"sku":          "sku",
+}
+
+// ParseImportCSV reads links from CSV with a header line. Columns are matched
+// by name regardless of case and unknown columns are ignored. Values that
+// cannot be parsed are reported on their row rather than failing the file.
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("CSV tidak dapat dibaca: %w", err)
+	}
+
+	fields := make([]string, len(header))
+	hasURL := false
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		fields[i] = importColumns[name]
+		hasURL = hasURL || fields[i] == "url"
+	}
+	if !hasURL {
+		return nil, ErrImportNoURLField
+	}
+
+	var rows []ImportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV tidak dapat dibaca: %w", err)
+		}
+
+		row := ImportRow{Line: line}
+		for i, value := range record {
+			if i >= len(fields) {
+				break
+			}
+			row.setField(fields[i], strings.TrimSpace(value))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (row *ImportRow) setField(field, value string) {
+	switch field {
+	case "title":
+		row.Title = &value
+	case "url":
+		row.URL = value
+	case "image_url":
+		row.ImageURL = &value
+	case "price":
+		if value == "" {
+			return
+		}
+		price, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			row.problems = append(row.problems, "price harus berupa angka bulat")
+			return
+		}
+		row.Price = &price
+	case "price_str":
+		row.PriceStr = &value
+	case "category":
+		row.Category = value
+	case "is_active":
+		if value == "" {
+			return
+		}
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			row.problems = append(row.problems, "is_active harus true atau false")
+			return
+		}
+		row.IsActive = &isActive
+	case "weight_grams":
+		if value == "" {
+			return
+		}
+		weight, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			row.problems = append(row.problems, "weight_grams harus berupa angka")
+			return
+		}
+		row.WeightGrams = &weight
+	case "karat":
+		if value == "" {
+			return
+		}
+		karat, err := strconv.Atoi(strings.TrimSuffix(strings.ToUpper(value), "K"))
+		if err != nil {
+			row.problems = append(row.problems, "karat harus berupa angka bulat")
+			return
+		}
+		row.Karat = &karat
+	case "product_type":
+		value = strings.ToLower(value)
+		row.ProductType = &value
+	case "brand":
+		row.Brand = &value
+	case "sku":
+		row.SKU = &value
+	}
+}
+
+// ParseImportJSON reads links from a JSON array of objects with the link's
+// field names and a category name.
+func ParseImportJSON(r io.Reader) ([]ImportRow, error) {
+	var rows []ImportRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("JSON tidak dapat dibaca: %w", err)
+	}
+
+	for i := range rows {
+		rows[i].Line = i + 1
+	}
+	return rows, nil
+}
+
+// importPlan is a validated row together with what it will change.
+type importPlan struct {
+	row      ImportRow
+	existing *models.Link
+	category string
+}
+
+// ImportLinks creates or updates links by URL and creates categories that
+// do not exist yet, matching them by name regardless of case. Nothing is
+// saved when any row is invalid or when dryRun is set; the report then shows
+// what would have happened.
+func ImportLinks(db *gorm.DB, rows []ImportRow, dryRun bool, actor Actor) (*ImportReport, error) {
+	report := &ImportReport{DryRun: dryRun, CategoriesCreated: []string{}, Rows: []ImportRowResult{}}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		categories, err := repositories.GetCategories(tx)
+		if err != nil {
+			return err
+		}
+		categoryIDs := map[string]uint{}
+		for _, category := range categories {
+			categoryIDs[strings.ToLower(strings.TrimSpace(category.Name))] = category.ID
+		}
+
+		plans := planImport(tx, rows, categoryIDs, report)
+		if report.Failed > 0 {
+			return ErrImportInvalid
+		}
+		if dryRun {
+			return nil
+		}
+
+		for _, name := range report.CategoriesCreated {
+			category := models.Category{Name: name}
+			if err := CreateCategory(tx, &category, actor); err != nil {
+				return err
+			}
+			categoryIDs[strings.ToLower(name)] = category.ID
+		}
+
+		for i, plan := range plans {
+			link, err := applyImportRow(tx, plan, categoryIDs, actor)
+			if err != nil {
+				return fmt.Errorf("baris %d: %w", plan.row.Line, err)
+			}
+			report.Rows[i].LinkID = link.ID
+		}
+		return nil
+	})
+
+	return report, err
+}
+
+func planImport(tx *gorm.DB, rows []ImportRow, categoryIDs map[string]uint, report *ImportReport) []importPlan {
+	plans := make([]importPlan, 0, len(rows))
+	seenURLs := map[string]int{}
+	seenSKUs := map[string]int{}
+	newCategories := map[string]bool{}
+
+	for _, row := range rows {
+		row.URL = strings.TrimSpace(row.URL)
+		category := strings.TrimSpace(row.Category)
+		problems := append([]string{}, row.problems...)
+
+		var existing *models.Link
+		switch {
+		case row.URL == "":
+			problems = append(problems, "url wajib diisi")
+		case !isWebURL(row.URL):
+			problems = append(problems, "url tidak valid")
+		default:
+			if line, ok := seenURLs[row.URL]; ok {
+				problems = append(problems, fmt.Sprintf("url sudah muncul di baris %d", line))
+			} else {
+				seenURLs[row.URL] = row.Line
+			}
+
+			if link, err := repositories.GetLinkByURL(tx, row.URL); err == nil {
+				existing = link
+			}
+		}
+
+		if (existing == nil && row.Title == nil) || (row.Title != nil && strings.TrimSpace(*row.Title) == "") {
+			problems = append(problems, "title wajib diisi")
+		}
+		if existing == nil && category == "" {
+			problems = append(problems, "category wajib diisi")
+		}
+		if row.ImageURL != nil && *row.ImageURL != "" && !isWebURL(*row.ImageURL) {
+			problems = append(problems, "image_url tidak valid")
+		}
+		if row.Price != nil && *row.Price < 0 {
+			problems = append(problems, "price tidak boleh negatif")
+		}
+		problems = append(problems, goldImportProblems(tx, row, existing, seenSKUs)...)
+
+		result := ImportRowResult{Line: row.Line, URL: row.URL, Category: category}
+		if row.Title != nil {
+			result.Title = *row.Title
+		}
+		if existing != nil {
+			result.LinkID = existing.ID
+			if row.Title == nil {
+				result.Title = existing.Title
+			}
+		}
+
+		switch {
+		case len(problems) > 0:
+			result.Action = ImportActionError
+			result.Errors = problems
+			report.Failed++
+		case existing != nil:
+			result.Action = ImportActionUpdate
+			report.Updated++
+		default:
+			result.Action = ImportActionCreate
+			report.Created++
+		}
+		report.Rows = append(report.Rows, result)
+
+		if category != "" && len(problems) == 0 {
+			key := strings.ToLower(category)
+			if _, ok := categoryIDs[key]; !ok && !newCategories[key] {
+				newCategories[key] = true
+				report.CategoriesCreated = append(report.CategoriesCreated, category)
+			}
+		}
+
+		plans = append(plans, importPlan{row: row, existing: existing, category: category})
+	}
+
+	return plans
+}
+
+// goldImportProblems validates the gold attributes of a row. SKUs must not
+// repeat within the file or belong to a link other than the one updated.
+func goldImportProblems(tx *gorm.DB, row ImportRow, existing *models.Link, seenSKUs map[string]int) []string {
+	var problems []string
+	if row.WeightGrams != nil && *row.WeightGrams < 0 {
+		problems = append(problems, "weight_grams tidak boleh negatif")
+	}
+	if row.Karat != nil && (*row.Karat < 0 || *row.Karat > models.MaxKarat) {
+		problems = append(problems, fmt.Sprintf("karat harus antara 0 dan %d, 0 berarti tidak diisi", models.MaxKarat))
+	}
+	if row.ProductType != nil && *row.ProductType != "" && !models.IsValidProductType(*row.ProductType) {
+		problems = append(problems, "product_type harus salah satu dari "+strings.Join(models.ProductTypes, ", "))
+	}
+
+	if row.SKU == nil || strings.TrimSpace(*row.SKU) == "" {
+		return problems
+	}
+	sku := strings.TrimSpace(*row.SKU)
+	if len(sku) > maxSKULength {
+		problems = append(problems, fmt.Sprintf("sku maksimal %d karakter", maxSKULength))
+	}
+	if line, ok := seenSKUs[sku]; ok {
+		problems = append(problems, fmt.Sprintf("sku sudah muncul di baris %d", line))
+	} else {
+		seenSKUs[sku] = row.Line
+	}
+
+	var linkID uint
+	if existing != nil {
+		linkID = existing.ID
+	}
+	if err := checkSKUAvailable(tx, sku, linkID); errors.Is(err, ErrSKUTaken) {
+		problems = append(problems, "sku sudah dipakai link lain")
+	}
+	return problems
+}
+
+func applyImportRow(tx *gorm.DB, plan importPlan, categoryIDs map[string]uint, actor Actor) (*models.Link, error) {
+	row := plan.row
+
+	link := models.Link{URL: row.URL, IsActive: true}
+	if plan.existing != nil {
+		link = *linkSnapshot(plan.existing)
+	}
+	if plan.category != "" {
+		link.CategoryID = categoryIDs[strings.ToLower(plan.category)]
+	}
+	if row.Title != nil {
+		link.Title = strings.TrimSpace(*row.Title)
+	}
+	if row.ImageURL != nil {
+		link.ImageURL = *row.ImageURL
+	}
+	if row.Price != nil {
+		link.Price = *row.Price
+	}
+	if row.PriceStr != nil {
+		adoptPriceStr(&link, *row.PriceStr, row.Price != nil)
+	}
+	if row.IsActive != nil {
+		link.IsActive = *row.IsActive
+	}
+	if row.WeightGrams != nil {
+		link.WeightGrams = *row.WeightGrams
+	}
+	if row.Karat != nil {
+		link.Karat = *row.Karat
+	}
+	if row.ProductType != nil {
+		link.ProductType = *row.ProductType
+	}
+	if row.Brand != nil {
+		link.Brand = *row.Brand
+	}
+	if row.SKU != nil {
+		link.SKU = *row.SKU
+	}
+
+	if plan.existing != nil {
+		return &link, UpdateLink(tx, plan.existing.ID, &link, actor)
+	}
+	return &link, CreateLink(tx, &link, actor)
+}
+
+func isWebURL(value string) bool {
+	parsed, err := url.ParseRequestURI(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
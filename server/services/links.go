@@ -2,6 +2,8 @@ package services
 
 import (
 	"errors"
+	"time"
+
 	"gorm.io/gorm"
 	"raya/models"
 	"raya/repositories"
@@ -11,64 +13,355 @@ func GetAllCategories(db *gorm.DB, includeEmpty bool) ([]models.Category, error)
 	return repositories.GetAllCategories(db, includeEmpty)
 }
 
-func GetCategories(db *gorm.DB) ([]models.Category, error) {
-	return repositories.GetCategories(db)
+// linkSortColumns are the sort keys accepted by the link lists.
+var linkSortColumns = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"price":        "price",
+	"order":        "\"order\"",
+	"category_id":  "category_id",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"weight_grams": "weight_grams",
+	"karat":        "karat",
+}
+
+var categorySortColumns = map[string]string{
+	"id":    "id",
+	"name":  "name",
+	"order": "\"order\"",
+}
+
+func GetCategories(db *gorm.DB, filter repositories.CategoryFilter, sort string, page, perPage int) (*Page[models.Category], error) {
+	var err error
+	if filter.Sort, err = sortClauses(sort, categorySortColumns, "order"); err != nil {
+		return nil, err
+	}
+	page, perPage = normalizePage(page, perPage)
+	filter.Offset = (page - 1) * perPage
+	filter.Limit = perPage
+
+	categories, total, err := repositories.GetCategoryList(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(categories, total, page, perPage), nil
+}
+
+func GetLinks(db *gorm.DB, filter repositories.LinkFilter, sort string, page, perPage int) (*Page[models.Link], error) {
+	return getLinkPage(db, repositories.GetLinks, filter, sort, "id", page, perPage)
 }
 
-func GetLinks(db *gorm.DB) ([]models.Link, error) {
-	return repositories.GetLinks(db)
+// GetAllLinks lists links with their category, by default grouped by
+// category in display order.
+func GetAllLinks(db *gorm.DB, filter repositories.LinkFilter, sort string, page, perPage int) (*Page[models.Link], error) {
+	return getLinkPage(db, repositories.GetAllLinks, filter, sort, "category_id,order", page, perPage)
 }
 
-func GetAllLinks(db *gorm.DB) ([]models.Link, error) {
-	return repositories.GetAllLinks(db)
+func getLinkPage(db *gorm.DB, find func(*gorm.DB, repositories.LinkFilter) ([]models.Link, int64, error), filter repositories.LinkFilter, sort, fallback string, page, perPage int) (*Page[models.Link], error) {
+	var err error
+	if filter.Sort, err = sortClauses(sort, linkSortColumns, fallback); err != nil {
+		return nil, err
+	}
+	page, perPage = normalizePage(page, perPage)
+	filter.Offset = (page - 1) * perPage
+	filter.Limit = perPage
+
+	links, total, err := find(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(links, total, page, perPage), nil
 }
 
-func GetCategoriesWithLinks(db *gorm.DB) ([]models.Category, error) {
-    return repositories.GetCategoriesWithLinks(db)
+// GetCategoriesWithLinks returns the public catalog. Prices are also
+// converted to currency unless it is empty or IDR.
+func GetCategoriesWithLinks(db *gorm.DB, filter repositories.GoldFilter, currency string) ([]models.Category, error) {
+    converter, err := converterFor(db, currency)
+    if err != nil {
+        return nil, err
+    }
+
+    categories, err := repositories.GetCategoriesWithLinks(db, filter)
+    if err != nil {
+        return nil, err
+    }
+
+    for i := range categories {
+        setPromoLabels(categories[i].Links)
+        if converter != nil {
+            converter.convertLinks(categories[i].Links)
+        }
+    }
+    return categories, nil
 }
 
 func GetLinkByID(db *gorm.DB, id uint) (*models.Link, error) {
 	return repositories.GetLinkByID(db, id)
 }
 
-func CreateLink(db *gorm.DB, link *models.Link) error {
+// validateLink checks the fields of a link that don't need the database.
+func validateLink(link *models.Link) error {
 	if link.Title == "" || link.URL == "" || link.CategoryID == 0 {
 		return errors.New("title, URL, and category are required")
 	}
+	if err := validatePublishWindow(link); err != nil {
+		return err
+	}
+	return validateGoldAttributes(link)
+}
+
+// prepareLink checks a validated link against the database and derives what
+// is not entered by hand: the price of auto-priced links, the price label and
+// the schedule state. id is the link's own ID, or 0 for a new link.
+func prepareLink(tx *gorm.DB, link *models.Link, id uint) error {
+	if err := checkSKUAvailable(tx, link.SKU, id); err != nil {
+		return err
+	}
+	if err := applyPricingRule(tx, link); err != nil {
+		return err
+	}
+	renderPriceStr(link)
+	if err := validatePromo(link); err != nil {
+		return err
+	}
+	link.ScheduleState = link.WindowStatus(time.Now())
+	return nil
+}
+
+func CreateLink(db *gorm.DB, link *models.Link, actor Actor) error {
+	if err := validateLink(link); err != nil {
+		return err
+	}
 	
-	return repositories.CreateLink(db, link)
+	// New links go to the end and are then moved to the requested position.
+	position := link.Order
+	link.Order = 0
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := prepareLink(tx, link, 0); err != nil {
+			return err
+		}
+		if err := repositories.CreateLink(tx, link); err != nil {
+			return err
+		}
+		if position > 0 && position != link.Order {
+			moved, err := moveLink(tx, link.CategoryID, link.ID, position)
+			if err != nil {
+				return err
+			}
+			link.Order = moved
+		}
+		return recordChange(tx, actor, models.AuditActionCreate, models.EntityLink, link.ID, nil, linkSnapshot(link))
+	})
 }
 
-func UpdateLink(db *gorm.DB, id uint, link *models.Link) error {
-	if link.Title == "" || link.URL == "" || link.CategoryID == 0 {
-		return errors.New("title, URL, and category are required")
+func UpdateLink(db *gorm.DB, id uint, link *models.Link, actor Actor) error {
+	if err := validateLink(link); err != nil {
+		return err
 	}
-	return repositories.UpdateLink(db, id, link)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		before, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := prepareLink(tx, link, id); err != nil {
+			return err
+		}
+		if link.CategoryID != before.CategoryID {
+			if err := relocateLink(tx, before, link.CategoryID); err != nil {
+				return err
+			}
+		}
+		if err := repositories.UpdateLink(tx, id, link); err != nil {
+			return err
+		}
+		if link.Order > 0 && link.Order != before.Order {
+			if _, err := moveLink(tx, link.CategoryID, id, link.Order); err != nil {
+				return err
+			}
+		}
+		after, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionUpdate, models.EntityLink, id, linkSnapshot(before), linkSnapshot(after))
+	})
+}
+
+func DeleteLink(db *gorm.DB, id uint, actor Actor) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		before, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := repositories.DeleteLink(tx, id); err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionDelete, models.EntityLink, id, linkSnapshot(before), nil)
+	})
 }
 
-func DeleteLink(db *gorm.DB, id uint) error {
-	return repositories.DeleteLink(db, id)
+// MoveLink puts a link into a category at a 1-based position, or at the end
+// when position is 0. Both the old and the new category are re-sequenced.
+func MoveLink(db *gorm.DB, id, categoryID uint, position int, actor Actor) (*models.Link, error) {
+	var moved *models.Link
+	err := db.Transaction(func(tx *gorm.DB) error {
+		before, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if categoryID != before.CategoryID {
+			if err := relocateLink(tx, before, categoryID); err != nil {
+				return err
+			}
+		}
+		if position > 0 {
+			if _, err := moveLink(tx, categoryID, id, position); err != nil {
+				return err
+			}
+		}
+
+		moved, err = repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionMove, models.EntityLink, id, linkSnapshot(before), linkSnapshot(moved))
+	})
+	return moved, err
+}
+
+// DuplicateLink copies a link to the end of a category, its own when
+// categoryID is 0. The copy starts inactive, without a publishing window and
+// without a SKU so it can be edited before going live.
+func DuplicateLink(db *gorm.DB, id, categoryID uint, actor Actor) (*models.Link, error) {
+	var duplicate *models.Link
+	err := db.Transaction(func(tx *gorm.DB) error {
+		source, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if categoryID == 0 {
+			categoryID = source.CategoryID
+		}
+		if _, err := repositories.GetCategoryByID(tx, categoryID); err != nil {
+			return err
+		}
+
+		copied := *linkSnapshot(source)
+		copied.ID = 0
+		copied.CategoryID = categoryID
+		copied.Order = 0
+		copied.IsActive = false
+		copied.PublishAt = nil
+		copied.UnpublishAt = nil
+		copied.ScheduleState = copied.WindowStatus(time.Now())
+		copied.SKU = ""
+		copied.CreatedAt = time.Time{}
+		copied.UpdatedAt = time.Time{}
+
+		if err := repositories.CreateLink(tx, &copied); err != nil {
+			return err
+		}
+
+		duplicate, err = repositories.GetLinkByID(tx, copied.ID)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionDuplicate, models.EntityLink, duplicate.ID, nil, linkSnapshot(duplicate))
+	})
+	return duplicate, err
 }
 
 func GetCategoryByID(db *gorm.DB, id uint) (*models.Category, error) {
 	return repositories.GetCategoryByID(db, id)
 }
 
-func CreateCategory(db *gorm.DB, category *models.Category) error {
+func validateCategory(category *models.Category) error {
 	if category.Name == "" {
 		return errors.New("category name is required")
 	}
+	return nil
+}
+
+func CreateCategory(db *gorm.DB, category *models.Category, actor Actor) error {
+	if err := validateCategory(category); err != nil {
+		return err
+	}
 	
-	return repositories.CreateCategory(db, category)
+	position := category.Order
+	category.Order = 0
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.CreateCategory(tx, category); err != nil {
+			return err
+		}
+		if position > 0 && position != category.Order {
+			moved, err := moveCategory(tx, category.ID, position)
+			if err != nil {
+				return err
+			}
+			category.Order = moved
+		}
+		return recordChange(tx, actor, models.AuditActionCreate, models.EntityCategory, category.ID, nil, categorySnapshot(category))
+	})
 }
 
-func UpdateCategory(db *gorm.DB, id uint, category *models.Category) error {
-	if category.Name == "" {
-		return errors.New("category name is required")
+func UpdateCategory(db *gorm.DB, id uint, category *models.Category, actor Actor) error {
+	if err := validateCategory(category); err != nil {
+		return err
 	}
-	return repositories.UpdateCategory(db, id, category)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		before, err := repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		beforeSnapshot := categorySnapshot(before)
+		if err := repositories.UpdateCategory(tx, id, category); err != nil {
+			return err
+		}
+		if category.Order > 0 && category.Order != before.Order {
+			if _, err := moveCategory(tx, id, category.Order); err != nil {
+				return err
+			}
+		}
+		after, err := repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionUpdate, models.EntityCategory, id, beforeSnapshot, categorySnapshot(after))
+	})
+}
+
+// DeleteCategory moves a category and its links to the trash. The repository
+// trashes the links itself; the foreign key cascade only runs on purge. The
+// audit entry keeps the deleted links in its before snapshot.
+func DeleteCategory(db *gorm.DB, id uint, actor Actor) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		before, err := repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := repositories.DeleteCategory(tx, id); err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionDelete, models.EntityCategory, id, before, nil)
+	})
+}
+
+// linkSnapshot drops the preloaded category so audit entries only hold the
+// link's own fields.
+func linkSnapshot(link *models.Link) *models.Link {
+	snapshot := *link
+	snapshot.Category = nil
+	return &snapshot
 }
 
-func DeleteCategory(db *gorm.DB, id uint) error {
-	return repositories.DeleteCategory(db, id)
+func categorySnapshot(category *models.Category) *models.Category {
+	snapshot := *category
+	snapshot.Links = nil
+	return &snapshot
 }
\ No newline at end of file
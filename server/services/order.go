@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+var ErrInvalidOrder = errors.New("daftar ID harus memuat setiap item tepat satu kali")
+
+// ReorderCategories rewrites the positions of all categories to follow ids,
+// which must list every category exactly once.
+func ReorderCategories(db *gorm.DB, ids []uint, actor Actor) ([]models.Category, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.LockOrdering(tx, 0); err != nil {
+			return err
+		}
+		current, err := repositories.GetCategoryIDs(tx)
+		if err != nil {
+			return err
+		}
+		if err := validateOrder(current, ids); err != nil {
+			return err
+		}
+
+		if err := repositories.ReorderCategories(tx, ids); err != nil {
+			return err
+		}
+		return recordAudit(tx, actor, models.AuditActionReorder, models.EntityCategory, 0, orderSnapshot(current), orderSnapshot(ids))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories.GetCategories(db)
+}
+
+// ReorderLinks rewrites the positions of the links in a category to follow
+// ids, which must list every link of the category exactly once.
+func ReorderLinks(db *gorm.DB, categoryID uint, ids []uint, actor Actor) ([]models.Link, error) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := repositories.GetCategoryByID(tx, categoryID); err != nil {
+			return err
+		}
+		if err := repositories.LockOrdering(tx, categoryID); err != nil {
+			return err
+		}
+		current, err := repositories.GetLinkIDs(tx, categoryID)
+		if err != nil {
+			return err
+		}
+		if err := validateOrder(current, ids); err != nil {
+			return err
+		}
+
+		if err := repositories.ReorderLinks(tx, ids); err != nil {
+			return err
+		}
+		return recordAudit(tx, actor, models.AuditActionReorder, models.EntityCategory, categoryID, orderSnapshot(current), orderSnapshot(ids))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories.GetCategoryLinks(db, categoryID)
+}
+
+func validateOrder(current, ids []uint) error {
+	if len(ids) != len(current) {
+		return ErrInvalidOrder
+	}
+
+	remaining := make(map[uint]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return ErrInvalidOrder
+		}
+		delete(remaining, id)
+	}
+	return nil
+}
+
+func orderSnapshot(ids []uint) map[string]interface{} {
+	return map[string]interface{}{"order": ids}
+}
+
+// moveLink puts a link at a 1-based position within its category, shifting
+// the links after it, and returns the position it ended up at.
+func moveLink(tx *gorm.DB, categoryID, id uint, position int) (int, error) {
+	if err := repositories.LockOrdering(tx, categoryID); err != nil {
+		return 0, err
+	}
+	ids, err := repositories.GetLinkIDs(tx, categoryID)
+	if err != nil {
+		return 0, err
+	}
+
+	ids, position = moveID(ids, id, position)
+	return position, repositories.ReorderLinks(tx, ids)
+}
+
+// relocateLink moves a link to the end of another category and closes the
+// gap it leaves in its old one.
+func relocateLink(tx *gorm.DB, link *models.Link, categoryID uint) error {
+	if _, err := repositories.GetCategoryByID(tx, categoryID); err != nil {
+		return err
+	}
+
+	// Both lists are locked in ID order so two opposite moves cannot deadlock.
+	first, second := link.CategoryID, categoryID
+	if first > second {
+		first, second = second, first
+	}
+	if err := repositories.LockOrdering(tx, first); err != nil {
+		return err
+	}
+	if err := repositories.LockOrdering(tx, second); err != nil {
+		return err
+	}
+
+	order, err := repositories.GetNextLinkOrder(tx, categoryID)
+	if err != nil {
+		return err
+	}
+	if err := repositories.SetLinkCategory(tx, link.ID, categoryID, order); err != nil {
+		return err
+	}
+
+	ids, err := repositories.GetLinkIDs(tx, link.CategoryID)
+	if err != nil {
+		return err
+	}
+	return repositories.ReorderLinks(tx, ids)
+}
+
+func moveCategory(tx *gorm.DB, id uint, position int) (int, error) {
+	if err := repositories.LockOrdering(tx, 0); err != nil {
+		return 0, err
+	}
+	ids, err := repositories.GetCategoryIDs(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	ids, position = moveID(ids, id, position)
+	return position, repositories.ReorderCategories(tx, ids)
+}
+
+// moveID returns ids with id placed at a 1-based position, clamped to the
+// length of the list.
+func moveID(ids []uint, id uint, position int) ([]uint, int) {
+	moved := make([]uint, 0, len(ids)+1)
+	for _, other := range ids {
+		if other != id {
+			moved = append(moved, other)
+		}
+	}
+
+	if position < 1 {
+		position = 1
+	}
+	if position > len(moved)+1 {
+		position = len(moved) + 1
+	}
+
+	moved = append(moved, 0)
+	copy(moved[position:], moved[position-1:])
+	moved[position-1] = id
+	return moved, position
+}
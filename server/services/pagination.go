@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+var ErrInvalidSort = errors.New("kolom sort tidak dikenal")
+
+// Page is the envelope of every paginated list. Next and Prev are filled in
+// by the controller, which knows the request URL, and stay null on the first
+// and last page.
+type Page[T any] struct {
+	Data    []T     `json:"data"`
+	Total   int64   `json:"total"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+	Next    *string `json:"next"`
+	Prev    *string `json:"prev"`
+}
+
+func newPage[T any](data []T, total int64, page, perPage int) *Page[T] {
+	if data == nil {
+		data = []T{}
+	}
+	return &Page[T]{Data: data, Total: total, Page: page, PerPage: perPage}
+}
+
+// HasNext reports whether there are items after this page.
+func (p *Page[T]) HasNext() bool {
+	return int64(p.Page*p.PerPage) < p.Total
+}
+
+// normalizePage falls back to the first page and the default page size for
+// out of range values, and caps the page size at MaxPerPage.
+func normalizePage(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	return page, perPage
+}
+
+// sortClauses turns a comma separated list of sort keys, each optionally
+// prefixed with "-" for descending order, into ORDER BY clauses. columns maps
+// the accepted keys to their column. The ID is always added last so pages
+// never overlap.
+func sortClauses(value string, columns map[string]string, fallback string) ([]string, error) {
+	if value == "" {
+		value = fallback
+	}
+
+	var clauses []string
+	hasID := false
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		direction := "asc"
+		if strings.HasPrefix(key, "-") {
+			key, direction = key[1:], "desc"
+		}
+
+		column, ok := columns[key]
+		if !ok {
+			return nil, ErrInvalidSort
+		}
+		hasID = hasID || key == "id"
+		clauses = append(clauses, column+" "+direction)
+	}
+
+	if !hasID {
+		clauses = append(clauses, "id asc")
+	}
+	return clauses, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+const (
+	DefaultPriceSeriesDays = 30
+	MaxPriceSeriesDays     = 366
+)
+
+// priceSeriesTimeZone decides where the days of the gold price series begin
+// and end.
+const priceSeriesTimeZone = "Asia/Jakarta"
+
+var ErrInvalidPriceSeries = fmt.Errorf("karat harus antara 1 dan %d dan days antara 1 dan %d", models.MaxKarat, MaxPriceSeriesDays)
+
+// PriceHistory is a link's current price and the changes that led to it.
+type PriceHistory struct {
+	LinkID   uint                 `json:"link_id"`
+	Price    int64                `json:"price"`
+	PriceStr string               `json:"price_str"`
+	Changes  []models.PriceChange `json:"changes"`
+}
+
+// GetLinkPriceHistory returns the price history of a link shown on the
+// public catalog, limited to [from, to) when they are set.
+func GetLinkPriceHistory(db *gorm.DB, id uint, from, to *time.Time) (*PriceHistory, error) {
+	link, err := repositories.GetVisibleLinkByID(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := repositories.GetPriceChanges(db, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []models.PriceChange{}
+	}
+
+	return &PriceHistory{LinkID: link.ID, Price: link.Price, PriceStr: link.PriceStr, Changes: changes}, nil
+}
+
+// GoldPriceSeries is the daily gold price of a karat over the last days,
+// today included. Days without quotes are left out.
+type GoldPriceSeries struct {
+	Karat    int                           `json:"karat"`
+	From     string                        `json:"from"`
+	To       string                        `json:"to"`
+	TimeZone string                        `json:"time_zone"`
+	Days     []repositories.DailyGoldPrice `json:"days"`
+}
+
+func GetDailyGoldPrices(db *gorm.DB, karat, days int) (*GoldPriceSeries, error) {
+	if karat < 1 || karat > models.MaxKarat || days < 1 || days > MaxPriceSeriesDays {
+		return nil, ErrInvalidPriceSeries
+	}
+
+	location, err := time.LoadLocation(priceSeriesTimeZone)
+	if err != nil {
+		// Western Indonesian Time has no daylight saving.
+		location = time.FixedZone("WIB", 7*60*60)
+	}
+	now := time.Now().In(location)
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	start := end.AddDate(0, 0, -days)
+
+	series, err := repositories.GetDailyGoldPrices(db, karat, start, end, priceSeriesTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		series = []repositories.DailyGoldPrice{}
+	}
+
+	return &GoldPriceSeries{
+		Karat:    karat,
+		From:     start.Format("2006-01-02"),
+		To:       end.AddDate(0, 0, -1).Format("2006-01-02"),
+		TimeZone: priceSeriesTimeZone,
+		Days:     series,
+	}, nil
+}
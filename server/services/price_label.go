@@ -0,0 +1,51 @@
+package services
+
+import (
+	"strings"
+
+	"raya/models"
+	"raya/utils"
+)
+
+// renderPriceStr sets PriceStr from Price. An override without text falls
+// back to the rendered price.
+func renderPriceStr(link *models.Link) {
+	if link.PriceStrOverride && strings.TrimSpace(link.PriceStr) == "" {
+		link.PriceStrOverride = false
+	}
+	link.PriceStr = priceStrFor(link, link.Price)
+}
+
+// adoptPriceStr takes a price label from outside, such as an import file.
+// A label that is just an amount sets Price, unless priceSet says Price was
+// given separately, and is rendered again; anything else is kept as an
+// override.
+func adoptPriceStr(link *models.Link, text string, priceSet bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		link.PriceStrOverride = false
+		return
+	}
+
+	parsed, err := utils.ParseRupiah(text)
+	if err == nil && parsed.Plain && (!priceSet || parsed.Amount == link.Price) {
+		link.Price = parsed.Amount
+		link.PriceFrom = parsed.From
+		link.PriceStrOverride = false
+		return
+	}
+	link.PriceStr = text
+	link.PriceStrOverride = true
+}
+
+// priceStrFor is the label of link at price.
+func priceStrFor(link *models.Link, price int64) string {
+	switch {
+	case link.PriceStrOverride:
+		return link.PriceStr
+	case link.PriceFrom:
+		return utils.FormatRupiahFrom(price)
+	default:
+		return utils.FormatRupiah(price)
+	}
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+const (
+	feedFetchTimeout = 15 * time.Second
+	maxFeedSize      = 1 << 20
+	// maxClockSkew is how far past now a quote or rate may be dated. A later
+	// one would outrank every real update until its time came.
+	maxClockSkew = 5 * time.Minute
+)
+
+var ErrInvalidQuote = errors.New("harga emas tidak valid")
+
+// PriceFeedActor is recorded as the author of price changes made by the
+// price feed.
+var PriceFeedActor = Actor{Username: "price-feed"}
+
+// PriceSource supplies gold quotes to RecordGoldPrices.
+type PriceSource interface {
+	// Name is stored with each quote to tell where it came from.
+	Name() string
+	Fetch(ctx context.Context) ([]models.GoldQuote, error)
+}
+
+// PriceFeed is the JSON document read from an HTTP price feed and accepted
+// for manual entry. Prices without their own quoted_at take the document's,
+// and the time the document is read when neither is set.
+type PriceFeed struct {
+	QuotedAt *time.Time      `json:"quoted_at"`
+	Prices   []PriceFeedItem `json:"prices" binding:"required"`
+}
+
+// PriceFeedItem holds the buy and sell price per gram of one karat.
+type PriceFeedItem struct {
+	Karat    int        `json:"karat"`
+	Buy      int64      `json:"buy"`
+	Sell     int64      `json:"sell"`
+	QuotedAt *time.Time `json:"quoted_at"`
+}
+
+func (feed *PriceFeed) Quotes(now time.Time) []models.GoldQuote {
+	quotedAt := now
+	if feed.QuotedAt != nil {
+		quotedAt = *feed.QuotedAt
+	}
+
+	quotes := make([]models.GoldQuote, 0, len(feed.Prices))
+	for _, item := range feed.Prices {
+		quote := models.GoldQuote{Karat: item.Karat, BuyPerGram: item.Buy, SellPerGram: item.Sell, QuotedAt: quotedAt}
+		if item.QuotedAt != nil {
+			quote.QuotedAt = *item.QuotedAt
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+// HTTPPriceSource reads a PriceFeed document from URL.
+type HTTPPriceSource struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s *HTTPPriceSource) Name() string {
+	return feedSourceName(s.URL)
+}
+
+func (s *HTTPPriceSource) Fetch(ctx context.Context) ([]models.GoldQuote, error) {
+	var feed PriceFeed
+	if err := fetchFeed(ctx, s.Client, s.URL, &feed); err != nil {
+		return nil, err
+	}
+	return feed.Quotes(time.Now()), nil
+}
+
+// feedSourceName names an HTTP feed after its host.
+func feedSourceName(feedURL string) string {
+	if parsed, err := url.Parse(feedURL); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return "http"
+}
+
+// fetchFeed decodes the JSON document at feedURL into v.
+func fetchFeed(ctx context.Context, client *http.Client, feedURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("feed %s returned %s", feedSourceName(feedURL), resp.Status)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxFeedSize)).Decode(v); err != nil {
+		return fmt.Errorf("feed %s is not valid JSON: %w", feedSourceName(feedURL), err)
+	}
+	return nil
+}
+
+// ManualPriceSource carries the prices an admin entered by hand.
+type ManualPriceSource struct {
+	Feed PriceFeed
+}
+
+func (s *ManualPriceSource) Name() string {
+	return "manual"
+}
+
+func (s *ManualPriceSource) Fetch(ctx context.Context) ([]models.GoldQuote, error) {
+	return s.Feed.Quotes(time.Now()), nil
+}
+
+// PriceUpdate reports what RecordGoldPrices did. Skipped counts quotes that
+// were not newer than the stored quote of their karat.
+type PriceUpdate struct {
+	Source   string             `json:"source"`
+	Quotes   []models.GoldQuote `json:"quotes"`
+	Skipped  int                `json:"skipped"`
+	Repriced int                `json:"repriced"`
+}
+
+// RecordGoldPrices fetches quotes from source, stores the ones that are newer
+// than what is known for their karat and reprices the links that follow the
+// gold price.
+func RecordGoldPrices(db *gorm.DB, source PriceSource, actor Actor) (*PriceUpdate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), feedFetchTimeout)
+	defer cancel()
+
+	quotes, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateQuotes(quotes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	update := &PriceUpdate{Source: source.Name(), Quotes: []models.GoldQuote{}}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i := range quotes {
+			quote := quotes[i]
+			quote.Source = update.Source
+
+			latest, err := repositories.GetLatestGoldQuote(tx, quote.Karat)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			// Feeds are polled repeatedly and mostly repeat the last quote.
+			if latest != nil && !quote.QuotedAt.After(latest.QuotedAt) {
+				update.Skipped++
+				continue
+			}
+
+			if err := repositories.CreateGoldQuote(tx, &quote); err != nil {
+				return err
+			}
+			update.Quotes = append(update.Quotes, quote)
+
+			repriced, err := repriceLinks(tx, &quote, actor)
+			if err != nil {
+				return err
+			}
+			update.Repriced += repriced
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return update, nil
+}
+
+func validateQuotes(quotes []models.GoldQuote, now time.Time) error {
+	if len(quotes) == 0 {
+		return fmt.Errorf("%w: tidak ada harga", ErrInvalidQuote)
+	}
+
+	seen := map[int]bool{}
+	for _, quote := range quotes {
+		if quote.Karat < 1 || quote.Karat > models.MaxKarat {
+			return fmt.Errorf("%w: karat harus antara 1 dan %d", ErrInvalidQuote, models.MaxKarat)
+		}
+		if quote.BuyPerGram <= 0 || quote.SellPerGram <= 0 {
+			return fmt.Errorf("%w: harga %dK harus lebih dari 0", ErrInvalidQuote, quote.Karat)
+		}
+		if quote.QuotedAt.After(now.Add(maxClockSkew)) {
+			return fmt.Errorf("%w: waktu harga %dK ada di masa depan", ErrInvalidQuote, quote.Karat)
+		}
+		if seen[quote.Karat] {
+			return fmt.Errorf("%w: karat %d muncul lebih dari sekali", ErrInvalidQuote, quote.Karat)
+		}
+		seen[quote.Karat] = true
+	}
+	return nil
+}
+
+// repriceLinks applies a new quote to the auto-priced links of its karat.
+func repriceLinks(tx *gorm.DB, quote *models.GoldQuote, actor Actor) (int, error) {
+	links, err := repositories.GetAutoPricedLinks(tx, quote.Karat)
+	if err != nil {
+		return 0, err
+	}
+
+	repriced := 0
+	for i := range links {
+		link := &links[i]
+		price := goldPrice(link, quote)
+		priceStr := priceStrFor(link, price)
+		if price == link.Price && priceStr == link.PriceStr {
+			continue
+		}
+
+		if err := repositories.UpdateLinkPrice(tx, link.ID, price, priceStr); err != nil {
+			return repriced, err
+		}
+		after, err := repositories.GetLinkByID(tx, link.ID)
+		if err != nil {
+			return repriced, err
+		}
+		if err := recordChange(tx, actor, models.AuditActionReprice, models.EntityLink, link.ID, linkSnapshot(link), linkSnapshot(after)); err != nil {
+			return repriced, err
+		}
+		repriced++
+	}
+
+	return repriced, nil
+}
+
+// applyPricingRule prices an auto-priced link from the latest quote of its
+// karat. Until a quote for the karat exists the link keeps the price it was
+// given. PriceStr is rendered afterwards by renderPriceStr.
+func applyPricingRule(tx *gorm.DB, link *models.Link) error {
+	if !link.AutoPrice {
+		return nil
+	}
+
+	quote, err := repositories.GetLatestGoldQuote(tx, link.Karat)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	link.Price = goldPrice(link, quote)
+	return nil
+}
+
+// repriceLink brings an auto-priced link that missed quotes, such as one
+// coming back from the trash, up to the latest quote of its karat.
+func repriceLink(tx *gorm.DB, link *models.Link) error {
+	if !link.AutoPrice {
+		return nil
+	}
+
+	quote, err := repositories.GetLatestGoldQuote(tx, link.Karat)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	price := goldPrice(link, quote)
+	priceStr := priceStrFor(link, price)
+	if price == link.Price && priceStr == link.PriceStr {
+		return nil
+	}
+	return repositories.UpdateLinkPrice(tx, link.ID, price, priceStr)
+}
+
+func goldPrice(link *models.Link, quote *models.GoldQuote) int64 {
+	return int64(math.Round(link.WeightGrams*float64(quote.SellPerGram))) + link.MakingFee
+}
+
+func GetLatestGoldPrices(db *gorm.DB) ([]models.GoldQuote, error) {
+	return repositories.GetLatestGoldQuotes(db)
+}
+
+func GetGoldPriceHistory(db *gorm.DB, filter repositories.GoldQuoteFilter, page, perPage int) (*Page[models.GoldQuote], error) {
+	page, perPage = normalizePage(page, perPage)
+	filter.Offset = (page - 1) * perPage
+	filter.Limit = perPage
+
+	quotes, total, err := repositories.GetGoldQuotes(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(quotes, total, page, perPage), nil
+}
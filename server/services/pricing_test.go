@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"raya/models"
+)
+
+func TestHTTPPriceSourceFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "../database/fixtures/price_feed.json")
+	}))
+	defer server.Close()
+
+	source := &HTTPPriceSource{URL: server.URL}
+	quotes, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	quotedAt := time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+	want := []models.GoldQuote{
+		{Karat: 24, BuyPerGram: 1480000, SellPerGram: 1560000, QuotedAt: quotedAt},
+		{Karat: 22, BuyPerGram: 1350000, SellPerGram: 1430000, QuotedAt: quotedAt},
+		{Karat: 18, BuyPerGram: 1090000, SellPerGram: 1170000, QuotedAt: quotedAt.Add(-30 * time.Minute)},
+	}
+	if len(quotes) != len(want) {
+		t.Fatalf("got %d quotes, want %d", len(quotes), len(want))
+	}
+	for i, quote := range quotes {
+		if quote.Karat != want[i].Karat || quote.BuyPerGram != want[i].BuyPerGram ||
+			quote.SellPerGram != want[i].SellPerGram || !quote.QuotedAt.Equal(want[i].QuotedAt) {
+			t.Errorf("quote %d = %+v, want %+v", i, quote, want[i])
+		}
+	}
+	if err := validateQuotes(quotes, quotedAt); err != nil {
+		t.Errorf("validateQuotes: %v", err)
+	}
+}
+
+func TestHTTPPriceSourceFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	source := &HTTPPriceSource{URL: server.URL}
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Fatal("Fetch succeeded on a failing feed")
+	}
+}
+
+func TestValidateQuotesRejectsFutureQuotes(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		quotedAt time.Time
+		valid    bool
+	}{
+		{now.Add(-time.Hour), true},
+		{now.Add(maxClockSkew / 2), true},
+		{now.Add(maxClockSkew + time.Minute), false},
+		{now.AddDate(1, 0, 0), false},
+	}
+
+	for _, tt := range tests {
+		quotes := []models.GoldQuote{{Karat: 24, BuyPerGram: 1480000, SellPerGram: 1560000, QuotedAt: tt.quotedAt}}
+		err := validateQuotes(quotes, now)
+		if tt.valid && err != nil {
+			t.Errorf("quote at %v rejected: %v", tt.quotedAt, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidQuote) {
+			t.Errorf("quote at %v: error = %v, want ErrInvalidQuote", tt.quotedAt, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"errors"
+
+	"raya/models"
+	"raya/utils"
+)
+
+// validatePromo checks a link's promo against its final price, so it runs
+// after the pricing rule.
+func validatePromo(link *models.Link) error {
+	if link.SalePrice < 0 {
+		return errors.New("sale_price must not be negative")
+	}
+	if link.DiscountPercent < 0 || link.DiscountPercent > 99 {
+		return errors.New("discount_percent must be between 0 and 99, 0 for none")
+	}
+	if link.SalePrice > 0 && link.DiscountPercent > 0 {
+		return errors.New("set either sale_price or discount_percent, not both")
+	}
+	if link.SalePrice > 0 && link.SalePrice >= link.Price {
+		return errors.New("sale_price must be below price")
+	}
+	if link.PromoStartAt != nil && link.PromoEndAt != nil && !link.PromoEndAt.After(*link.PromoStartAt) {
+		return errors.New("promo_end_at must be after promo_start_at")
+	}
+	if (link.PromoStartAt != nil || link.PromoEndAt != nil) && link.SalePrice == 0 && link.DiscountPercent == 0 {
+		return errors.New("promo dates need a sale_price or discount_percent")
+	}
+	return nil
+}
+
+// setPromoLabels renders the sale price of the links that are on promo.
+func setPromoLabels(links []models.Link) {
+	for i := range links {
+		if promo := links[i].Promo; promo != nil {
+			promo.SalePriceStr = utils.FormatRupiahDiscount(promo.OriginalPrice, promo.SalePrice)
+		}
+	}
+}
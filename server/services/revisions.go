@@ -0,0 +1,188 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+type RevisionDiff struct {
+	From    int                    `json:"from"`
+	To      int                    `json:"to"`
+	Changes map[string]fieldChange `json:"changes"`
+}
+
+// systemActor is recorded on revisions the server stores on its own.
+var systemActor = Actor{Username: "system"}
+
+// recordChange writes the audit entry for a mutation and stores the new
+// state of the entity as its next revision.
+func recordChange(tx *gorm.DB, actor Actor, action, entityType string, entityID uint, before, after interface{}) error {
+	if err := recordAudit(tx, actor, action, entityType, entityID, before, after); err != nil {
+		return err
+	}
+
+	if err := repositories.LockEntity(tx, entityType, entityID); err != nil {
+		return err
+	}
+	latest, err := repositories.GetLatestRevisionNumber(tx, entityType, entityID)
+	if err != nil {
+		return err
+	}
+	// Entities created before revisions existed get their previous state
+	// stored first, so the very first edit can still be rolled back.
+	if latest == 0 && before != nil {
+		latest++
+		if err := createRevision(tx, systemActor, models.RevisionActionImport, entityType, entityID, latest, before); err != nil {
+			return err
+		}
+	}
+
+	state := after
+	if state == nil {
+		state = before
+	}
+	return createRevision(tx, actor, action, entityType, entityID, latest+1, state)
+}
+
+func createRevision(tx *gorm.DB, actor Actor, action, entityType string, entityID uint, number int, state interface{}) error {
+	switch entity := state.(type) {
+	case *models.Link:
+		state = linkSnapshot(entity)
+	case *models.Category:
+		state = categorySnapshot(entity)
+	}
+
+	snapshot, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	revision := models.Revision{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Number:        number,
+		Action:        action,
+		Snapshot:      snapshot,
+		ActorID:       actor.AdminID,
+		ActorUsername: actor.Username,
+	}
+	return repositories.CreateRevision(tx, &revision)
+}
+
+func GetRevisions(db *gorm.DB, entityType string, entityID uint) ([]models.Revision, error) {
+	return repositories.GetRevisions(db, entityType, entityID)
+}
+
+func DiffRevisions(db *gorm.DB, entityType string, entityID uint, from, to int) (*RevisionDiff, error) {
+	fromRevision, err := repositories.GetRevision(db, entityType, entityID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := repositories.GetRevision(db, entityType, entityID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(fromRevision.Snapshot, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(toRevision.Snapshot, &after); err != nil {
+		return nil, err
+	}
+
+	// Bookkeeping fields always differ and say nothing about the content.
+	// The link status and promo are derived from the time the snapshot was
+	// taken.
+	for _, field := range []string{"updated_at", "deleted_at", "status", "promo"} {
+		delete(before, field)
+		delete(after, field)
+	}
+
+	return &RevisionDiff{From: from, To: to, Changes: diffSnapshots(before, after)}, nil
+}
+
+// RollbackLink restores the link's content from a revision. The rollback is
+// recorded as a new revision rather than rewriting history.
+func RollbackLink(db *gorm.DB, id uint, number int, actor Actor) (*models.Link, error) {
+	var restored *models.Link
+	err := db.Transaction(func(tx *gorm.DB) error {
+		revision, err := repositories.GetRevision(tx, models.EntityLink, id, number)
+		if err != nil {
+			return err
+		}
+
+		var snapshot models.Link
+		if err := json.Unmarshal(revision.Snapshot, &snapshot); err != nil {
+			return err
+		}
+
+		before, err := repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := repositories.GetCategoryByID(tx, snapshot.CategoryID); err != nil {
+			return errors.New("kategori pada revisi ini sudah tidak ada")
+		}
+		// Old revisions predate later rules, such as rendered price labels,
+		// so the snapshot goes through the same checks as an edit.
+		if err := validateLink(&snapshot); err != nil {
+			return err
+		}
+		if err := prepareLink(tx, &snapshot, id); err != nil {
+			return err
+		}
+		if snapshot.CategoryID != before.CategoryID {
+			if err := relocateLink(tx, before, snapshot.CategoryID); err != nil {
+				return err
+			}
+		}
+
+		if err := repositories.UpdateLink(tx, id, &snapshot); err != nil {
+			return err
+		}
+
+		restored, err = repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionRollback, models.EntityLink, id, linkSnapshot(before), linkSnapshot(restored))
+	})
+	return restored, err
+}
+
+func RollbackCategory(db *gorm.DB, id uint, number int, actor Actor) (*models.Category, error) {
+	var restored *models.Category
+	err := db.Transaction(func(tx *gorm.DB) error {
+		revision, err := repositories.GetRevision(tx, models.EntityCategory, id, number)
+		if err != nil {
+			return err
+		}
+
+		var snapshot models.Category
+		if err := json.Unmarshal(revision.Snapshot, &snapshot); err != nil {
+			return err
+		}
+
+		before, err := repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		beforeSnapshot := categorySnapshot(before)
+
+		if err := repositories.UpdateCategory(tx, id, &snapshot); err != nil {
+			return err
+		}
+
+		restored, err = repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionRollback, models.EntityCategory, id, beforeSnapshot, categorySnapshot(restored))
+	})
+	return restored, err
+}
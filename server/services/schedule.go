@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+// schedulerActor is recorded as the author of changes made by the
+// publishing scheduler.
+var schedulerActor = Actor{Username: "scheduler"}
+
+func validatePublishWindow(link *models.Link) error {
+	if link.PublishAt != nil && link.UnpublishAt != nil && !link.UnpublishAt.After(*link.PublishAt) {
+		return errors.New("unpublish_at must be after publish_at")
+	}
+	return nil
+}
+
+// ApplyPublishingSchedule activates links whose window has opened and
+// deactivates links whose window has closed. Each link is acted on once per
+// window status, so an admin can still switch a live link off by hand.
+func ApplyPublishingSchedule(db *gorm.DB) (int, int, error) {
+	now := time.Now()
+	links, err := repositories.GetScheduledLinks(db, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	published, unpublished := 0, 0
+	for i := range links {
+		link := &links[i]
+		state := link.WindowStatus(now)
+		if state == link.ScheduleState {
+			continue
+		}
+
+		isActive, action := link.IsActive, ""
+		switch {
+		case state == models.LinkStatusLive && !link.IsActive:
+			isActive, action = true, models.AuditActionPublish
+		case state == models.LinkStatusExpired && link.IsActive:
+			isActive, action = false, models.AuditActionUnpublish
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := repositories.ApplyScheduleState(tx, link.ID, state, isActive); err != nil {
+				return err
+			}
+			if action == "" {
+				return nil
+			}
+
+			after, err := repositories.GetLinkByID(tx, link.ID)
+			if err != nil {
+				return err
+			}
+			return recordChange(tx, schedulerActor, action, models.EntityLink, link.ID, linkSnapshot(link), linkSnapshot(after))
+		})
+		if err != nil {
+			return published, unpublished, err
+		}
+
+		switch action {
+		case models.AuditActionPublish:
+			published++
+		case models.AuditActionUnpublish:
+			unpublished++
+		}
+	}
+
+	return published, unpublished, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"gorm.io/gorm"
+	"raya/repositories"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+
+	// fuzzyMinSimilarity is the trigram similarity a link needs to be
+	// returned when full-text search finds nothing.
+	fuzzyMinSimilarity = 0.3
+
+	// Postgres marks matches with these, and highlightSnippet turns them
+	// into <mark> tags once the rest of the title is escaped.
+	searchMarkStart = "{{mark}}"
+	searchMarkEnd   = "{{/mark}}"
+)
+
+var ErrEmptySearch = errors.New("kata kunci pencarian wajib diisi")
+
+// SearchResult lists the matching links, best first. Fuzzy is set when no
+// link matched the query exactly and the results come from the trigram
+// fallback.
+type SearchResult struct {
+	Query string                   `json:"query"`
+	Fuzzy bool                     `json:"fuzzy"`
+	Data  []repositories.SearchHit `json:"data"`
+}
+
+// SearchLinks searches the public catalog by product title and category
+// name. Snippets are safe to insert as HTML. Prices are also converted to
+// currency unless it is empty or IDR.
+func SearchLinks(db *gorm.DB, query string, limit int, currency string) (*SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearch
+	}
+	converter, err := converterFor(db, currency)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+
+	options := fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=true`, searchMarkStart, searchMarkEnd)
+	hits, err := repositories.SearchLinks(db, query, options, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	fuzzy := false
+	if len(hits) == 0 {
+		fuzzy = true
+		hits, err = repositories.SearchLinksFuzzy(db, query, options, fuzzyMinSimilarity, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if hits == nil {
+		hits = []repositories.SearchHit{}
+	}
+	for i := range hits {
+		hits[i].Snippet = highlightSnippet(hits[i].Snippet)
+		if converter != nil {
+			hits[i].DisplayPrice = converter.displayPrice(hits[i].Price, nil)
+		}
+	}
+
+	return &SearchResult{Query: query, Fuzzy: fuzzy, Data: hits}, nil
+}
+
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer(searchMarkStart, "<mark>", searchMarkEnd, "</mark>").Replace(escaped)
+}
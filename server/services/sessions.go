@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+// sessionTouchInterval limits how often last_seen_at is written while a
+// session is in use.
+const sessionTouchInterval = time.Minute
+
+var ErrSessionEnded = errors.New("sesi sudah berakhir")
+
+// Client describes the device a request came from.
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// GetSessions lists the admin's active sessions, flagging the one identified
+// by currentFamilyID.
+func GetSessions(db *gorm.DB, adminID uint, currentFamilyID string) ([]models.Session, error) {
+	sessions, err := repositories.GetActiveSessions(db, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].FamilyID == currentFamilyID
+	}
+	return sessions, nil
+}
+
+// RevokeSession signs out one of the admin's own sessions.
+func RevokeSession(db *gorm.DB, adminID, sessionID uint) error {
+	session, err := repositories.GetSessionByID(db, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.AdminID != adminID {
+		return gorm.ErrRecordNotFound
+	}
+
+	return repositories.RevokeSession(db, session.FamilyID)
+}
+
+// RevokeAllSessions signs an admin out on every device.
+func RevokeAllSessions(db *gorm.DB, adminID uint) error {
+	if _, err := repositories.GetUserByID(db, adminID); err != nil {
+		return err
+	}
+	return repositories.RevokeAdminSessions(db, adminID)
+}
+
+// ValidateSession checks that the session behind an access token is still
+// active and records that it was seen.
+func ValidateSession(db *gorm.DB, familyID string, adminID uint, ip string) error {
+	session, err := repositories.GetSessionByFamilyID(db, familyID)
+	if err != nil || session.AdminID != adminID {
+		return ErrSessionEnded
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		return ErrSessionEnded
+	}
+
+	if now.Sub(session.LastSeenAt) > sessionTouchInterval || session.IP != ip {
+		return repositories.TouchSession(db, session.ID, ip, now)
+	}
+	return nil
+}
+
+func CleanupSessions(db *gorm.DB) (int64, error) {
+	return repositories.DeleteExpiredSessions(db)
+}
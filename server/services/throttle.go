@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	MaxFailedLogins  = 5
+	LockoutDuration  = 15 * time.Minute
+	loginBackoffBase = time.Second
+	loginBackoffMax  = 5 * time.Minute
+)
+
+// TooManyAttemptsError is returned when a login is rejected before the
+// password is checked because of earlier failures.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("terlalu banyak percobaan login, coba lagi dalam %d detik", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+type loginAttempt struct {
+	failures    int
+	lastFailure time.Time
+}
+
+// loginAttempts tracks recent failures per IP ("ip:" keys) and per username
+// ("user:" keys). Usernames are tracked whether or not the account exists so
+// throttling does not reveal which usernames are valid.
+var loginAttempts = struct {
+	sync.Mutex
+	byKey     map[string]*loginAttempt
+	lastPrune time.Time
+}{byKey: map[string]*loginAttempt{}}
+
+// loginDelay returns how long after its last failure a key must wait. Each
+// failure doubles the wait, and from MaxFailedLogins on the key is locked out
+// for LockoutDuration.
+func loginDelay(failures int) time.Duration {
+	if failures == 0 {
+		return 0
+	}
+	if failures >= MaxFailedLogins {
+		return LockoutDuration
+	}
+
+	delay := loginBackoffBase << (failures - 1)
+	if delay > loginBackoffMax {
+		return loginBackoffMax
+	}
+	return delay
+}
+
+func checkLoginThrottle(keys ...string) error {
+	loginAttempts.Lock()
+	defer loginAttempts.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range keys {
+		attempt, ok := loginAttempts.byKey[key]
+		if !ok {
+			continue
+		}
+		if remaining := attempt.lastFailure.Add(loginDelay(attempt.failures)).Sub(now); remaining > wait {
+			wait = remaining
+		}
+	}
+
+	if wait > 0 {
+		return &TooManyAttemptsError{RetryAfter: wait}
+	}
+	return nil
+}
+
+func recordLoginFailure(keys ...string) {
+	loginAttempts.Lock()
+	defer loginAttempts.Unlock()
+
+	now := time.Now()
+	pruneLoginAttempts(now)
+
+	for _, key := range keys {
+		attempt, ok := loginAttempts.byKey[key]
+		if !ok || now.Sub(attempt.lastFailure) > LockoutDuration {
+			attempt = &loginAttempt{}
+			loginAttempts.byKey[key] = attempt
+		}
+		attempt.failures++
+		attempt.lastFailure = now
+	}
+}
+
+func resetLoginFailures(keys ...string) {
+	loginAttempts.Lock()
+	defer loginAttempts.Unlock()
+
+	for _, key := range keys {
+		delete(loginAttempts.byKey, key)
+	}
+}
+
+// pruneLoginAttempts forgets keys whose last failure is older than the
+// lockout window. Callers must hold the lock.
+func pruneLoginAttempts(now time.Time) {
+	if now.Sub(loginAttempts.lastPrune) < time.Minute {
+		return
+	}
+	loginAttempts.lastPrune = now
+
+	for key, attempt := range loginAttempts.byKey {
+		if now.Sub(attempt.lastFailure) > LockoutDuration {
+			delete(loginAttempts.byKey, key)
+		}
+	}
+}
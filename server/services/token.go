@@ -0,0 +1,77 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+	"raya/utils"
+)
+
+// revokedTokens mirrors the revoked_tokens table so AuthMiddleware does not
+// need a query per request. It is filled by LoadRevokedTokens at startup and
+// resynced by the cleanup job.
+var revokedTokens = struct {
+	sync.RWMutex
+	byJTI map[string]time.Time
+}{byJTI: map[string]time.Time{}}
+
+func LoadRevokedTokens(db *gorm.DB) error {
+	tokens, err := repositories.GetActiveRevokedTokens(db)
+	if err != nil {
+		return err
+	}
+
+	cache := make(map[string]time.Time, len(tokens))
+	for _, token := range tokens {
+		cache[token.JTI] = token.ExpiresAt
+	}
+
+	revokedTokens.Lock()
+	revokedTokens.byJTI = cache
+	revokedTokens.Unlock()
+	return nil
+}
+
+func RevokeToken(db *gorm.DB, jti string, adminID uint, expiresAt time.Time) error {
+	token := models.RevokedToken{
+		JTI:       jti,
+		AdminID:   adminID,
+		ExpiresAt: expiresAt,
+	}
+	if err := repositories.CreateRevokedToken(db, &token); err != nil {
+		return err
+	}
+
+	revokedTokens.Lock()
+	revokedTokens.byJTI[jti] = expiresAt
+	revokedTokens.Unlock()
+	return nil
+}
+
+func IsTokenRevoked(jti string) bool {
+	revokedTokens.RLock()
+	defer revokedTokens.RUnlock()
+	_, ok := revokedTokens.byJTI[jti]
+	return ok
+}
+
+// CleanupRevokedTokens drops entries whose token has expired anyway and
+// reloads the cache from the table.
+func CleanupRevokedTokens(db *gorm.DB) (int64, error) {
+	deleted, err := repositories.DeleteExpiredRevokedTokens(db)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, LoadRevokedTokens(db)
+}
+
+func CleanupRefreshTokens(db *gorm.DB) (int64, error) {
+	return repositories.DeleteExpiredRefreshTokens(db)
+}
+
+func GetJWKS() (utils.JWKSet, error) {
+	return utils.PublicJWKS()
+}
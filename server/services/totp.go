@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+	"raya/utils"
+)
+
+const (
+	totpIssuer        = "Sekawan"
+	recoveryCodeCount = 10
+)
+
+var ErrInvalidTwoFactorCode = errors.New("kode 2FA tidak valid")
+
+type TOTPSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// SetupTOTP generates a new secret for the admin. 2FA stays disabled until
+// the secret is confirmed with ConfirmTOTP.
+func SetupTOTP(db *gorm.DB, adminID uint) (*TOTPSetup, error) {
+	admin, err := repositories.GetUserByID(db, adminID)
+	if err != nil {
+		return nil, errors.New("admin tidak ditemukan")
+	}
+	if admin.TOTPEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repositories.UpdateTOTPSecret(db, adminID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSetup{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(totpIssuer, admin.Username, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables 2FA once the admin proves their authenticator works,
+// and returns the recovery codes. The codes are only stored hashed, so this
+// is the only time they can be shown.
+func ConfirmTOTP(db *gorm.DB, adminID uint, code string) ([]string, error) {
+	admin, err := repositories.GetUserByID(db, adminID)
+	if err != nil {
+		return nil, errors.New("admin tidak ditemukan")
+	}
+	if admin.TOTPEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+	if admin.TOTPSecret == "" {
+		return nil, errors.New("2FA belum disiapkan")
+	}
+
+	step, ok := utils.ValidateTOTP(admin.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	records := make([]models.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		raw, err := utils.GenerateRandomString(5)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+		records[i] = models.RecoveryCode{AdminID: adminID, CodeHash: utils.HashToken(raw)}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := repositories.ReplaceRecoveryCodes(tx, adminID, records); err != nil {
+			return err
+		}
+		return repositories.EnableTOTP(tx, adminID, step)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func DisableTOTP(db *gorm.DB, adminID uint, password string) error {
+	admin, err := repositories.GetUserByID(db, adminID)
+	if err != nil {
+		return errors.New("admin tidak ditemukan")
+	}
+
+	if !utils.CheckPassword(password, admin.Password) {
+		return errors.New("password salah")
+	}
+
+	return repositories.DisableTOTP(db, adminID)
+}
+
+// VerifyTwoFactorLogin completes a login started by LoginUser. code may be a
+// TOTP code or one of the admin's unused recovery codes.
+func VerifyTwoFactorLogin(db *gorm.DB, challengeToken, code string, client Client) (*LoginResult, error) {
+	adminID, err := utils.ParseChallengeJWT(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ipKey, userKey := "ip:"+client.IP, "2fa:"+strconv.FormatUint(uint64(adminID), 10)
+	if err := checkLoginThrottle(ipKey, userKey); err != nil {
+		return nil, err
+	}
+
+	admin, err := repositories.GetUserByID(db, adminID)
+	if err != nil || !admin.TOTPEnabled {
+		return nil, errors.New("challenge token tidak valid")
+	}
+
+	ok, err := verifySecondFactor(db, admin, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		recordLoginFailure(ipKey, userKey)
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	resetLoginFailures(userKey)
+	pair, err := startSession(db, admin, client)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{TokenPair: pair, MustChangePassword: admin.MustChangePassword}, nil
+}
+
+func verifySecondFactor(db *gorm.DB, admin *models.Admin, code string) (bool, error) {
+	if step, ok := utils.ValidateTOTP(admin.TOTPSecret, code, time.Now()); ok {
+		return repositories.UpdateTOTPLastStep(db, admin.ID, step)
+	}
+
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if normalized == "" {
+		return false, nil
+	}
+	return repositories.UseRecoveryCode(db, admin.ID, utils.HashToken(normalized))
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"raya/models"
+	"raya/repositories"
+)
+
+var ErrCategoryTrashed = errors.New("kategori link ini masih di tempat sampah, pulihkan kategorinya terlebih dahulu")
+
+func GetTrashedLinks(db *gorm.DB) ([]models.Link, error) {
+	return repositories.GetTrashedLinks(db)
+}
+
+func GetTrashedCategories(db *gorm.DB) ([]models.Category, error) {
+	return repositories.GetTrashedCategories(db)
+}
+
+func RestoreLink(db *gorm.DB, id uint, actor Actor) (*models.Link, error) {
+	var restored *models.Link
+	err := db.Transaction(func(tx *gorm.DB) error {
+		link, err := repositories.GetTrashedLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := repositories.GetCategoryByID(tx, link.CategoryID); err != nil {
+			return ErrCategoryTrashed
+		}
+		if err := checkSKUAvailable(tx, link.SKU, id); err != nil {
+			return err
+		}
+
+		if err := repositories.RestoreLink(tx, link); err != nil {
+			return err
+		}
+		if err := repriceLink(tx, link); err != nil {
+			return err
+		}
+
+		restored, err = repositories.GetLinkByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionRestore, models.EntityLink, id, linkSnapshot(link), linkSnapshot(restored))
+	})
+	return restored, err
+}
+
+// RestoreCategory brings a category back together with the links that were
+// deleted along with it.
+func RestoreCategory(db *gorm.DB, id uint, actor Actor) (*models.Category, error) {
+	var restored *models.Category
+	err := db.Transaction(func(tx *gorm.DB) error {
+		category, err := repositories.GetTrashedCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		links, err := repositories.GetLinksTrashedWithCategory(tx, category)
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			if err := checkSKUAvailable(tx, link.SKU, link.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := repositories.RestoreCategory(tx, category); err != nil {
+			return err
+		}
+		for i := range links {
+			if err := repriceLink(tx, &links[i]); err != nil {
+				return err
+			}
+		}
+
+		restored, err = repositories.GetCategoryByID(tx, id)
+		if err != nil {
+			return err
+		}
+		return recordChange(tx, actor, models.AuditActionRestore, models.EntityCategory, id, category, restored)
+	})
+	return restored, err
+}
+
+// PurgeTrash permanently removes everything that has been in the trash for
+// longer than retention.
+func PurgeTrash(db *gorm.DB, retention time.Duration) (int64, int64, error) {
+	return repositories.PurgeTrash(db, time.Now().Add(-retention))
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CurrencyIDR is the currency prices are stored in.
+const CurrencyIDR = "IDR"
+
+// currencyFormat describes how a currency is written in its home locale.
+type currencyFormat struct {
+	symbol    string
+	space     bool
+	thousands string
+	decimal   string
+	decimals  int
+}
+
+var currencyFormats = map[string]currencyFormat{
+	CurrencyIDR: {symbol: "Rp", space: true, thousands: ".", decimal: ",", decimals: 0},
+	"MYR":       {symbol: "RM", space: false, thousands: ",", decimal: ".", decimals: 2},
+	"SGD":       {symbol: "S$", space: false, thousands: ",", decimal: ".", decimals: 2},
+}
+
+// IsSupportedCurrency reports whether prices can be shown in the ISO 4217
+// currency code.
+func IsSupportedCurrency(code string) bool {
+	_, ok := currencyFormats[code]
+	return ok
+}
+
+// CurrencyDecimals is the number of minor digits of a supported currency.
+func CurrencyDecimals(code string) int {
+	return currencyFormats[code].decimals
+}
+
+// FormatMoney writes an amount the way the currency's home locale does, such
+// as "Rp 1.250.000", "RM1,234.50" or "S$1,234.50".
+func FormatMoney(code string, amount float64) string {
+	format, ok := currencyFormats[code]
+	if !ok {
+		return strconv.FormatFloat(amount, 'f', 2, 64) + " " + code
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatFloat(amount, 'f', format.decimals, 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+	integer = strings.ReplaceAll(groupThousands(integer), ".", format.thousands)
+
+	out := sign + format.symbol
+	if format.space {
+		out += " "
+	}
+	out += integer
+	if format.decimals > 0 {
+		out += format.decimal + fraction
+	}
+	return out
+}
+
+// RoundMoney rounds an amount to the minor unit of a supported currency.
+func RoundMoney(code string, amount float64) float64 {
+	scale := math.Pow(10, float64(CurrencyDecimals(code)))
+	return math.Round(amount*scale) / scale
+}
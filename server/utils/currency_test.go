@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		code   string
+		amount float64
+		want   string
+	}{
+		{CurrencyIDR, 1250000, "Rp 1.250.000"},
+		{CurrencyIDR, 999, "Rp 999"},
+		{CurrencyIDR, -816000, "-Rp 816.000"},
+		{"MYR", 231.79, "RM231.79"},
+		{"MYR", 1234.5, "RM1,234.50"},
+		{"MYR", 0, "RM0.00"},
+		{"SGD", 1234567.89, "S$1,234,567.89"},
+		{"SGD", 0.5, "S$0.50"},
+		{"USD", 12.5, "12.50 USD"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatMoney(tt.code, tt.amount); got != tt.want {
+			t.Errorf("FormatMoney(%q, %v) = %q, want %q", tt.code, tt.amount, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"raya/models"
@@ -10,26 +9,46 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var SecretKey = []byte(os.Getenv("JWT_SECRET_KEY"))
+const (
+	AccessTokenTTL    = 15 * time.Minute
+	RefreshTokenTTL   = 12 * time.Hour
+	ChallengeTokenTTL = 5 * time.Minute
+)
+
+// Token types carried in the "typ" claim. Only access tokens are accepted by
+// ParseJWT; challenge tokens can only be exchanged at /login/2fa.
+const (
+	tokenTypeAccess    = "access"
+	tokenTypeChallenge = "2fa_challenge"
+)
+
+// GenerateJWT issues a short-lived access token. sessionID ties the token to
+// the refresh token family it was issued from.
+func GenerateJWT(user models.Admin, sessionID string) (string, error) {
+	jti, err := GenerateRandomString(16)
+	if err != nil {
+		return "", err
+	}
 
-func GenerateJWT(user models.Admin) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
+		"jti":      jti,
+		"typ":      tokenTypeAccess,
 		"id":       user.ID,
 		"username": user.Username,
-		"exp":      time.Now().Add(time.Hour * 24).Unix(),
+		"sid":      sessionID,
+		"iat":      now.Unix(),
+		"exp":      now.Add(AccessTokenTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(SecretKey)
+	return signToken(claims)
 }
 
 func ParseJWT(tokenString string) (*jwt.Token, *models.Admin, error) {
 	claims := jwt.MapClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return SecretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey)
 
-	if err != nil || !token.Valid {
+	if err != nil || !token.Valid || claims["typ"] != tokenTypeAccess {
 		return nil, nil, errors.New("token tidak valid")
 	}
 
@@ -46,4 +65,64 @@ func ParseJWT(tokenString string) (*jwt.Token, *models.Admin, error) {
 	}
 
 	return token, user, nil
-}
\ No newline at end of file
+}
+
+// GetTokenID returns the jti and expiry of a parsed token.
+func GetTokenID(token *jwt.Token) (string, time.Time, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", time.Time{}, errors.New("token tidak valid")
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return "", time.Time{}, errors.New("token tidak memiliki jti")
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return "", time.Time{}, errors.New("token tidak memiliki masa berlaku")
+	}
+
+	return jti, exp.Time, nil
+}
+
+// GetSessionID returns the refresh token family a token was issued from.
+func GetSessionID(token *jwt.Token) string {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	sid, _ := claims["sid"].(string)
+	return sid
+}
+
+// GenerateChallengeJWT issues the short-lived token returned by /login when
+// the admin still has to provide a TOTP code.
+func GenerateChallengeJWT(user models.Admin) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"typ": tokenTypeChallenge,
+		"id":  user.ID,
+		"iat": now.Unix(),
+		"exp": now.Add(ChallengeTokenTTL).Unix(),
+	}
+
+	return signToken(claims)
+}
+
+func ParseChallengeJWT(tokenString string) (uint, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey)
+
+	if err != nil || !token.Valid || claims["typ"] != tokenTypeChallenge {
+		return 0, errors.New("challenge token tidak valid")
+	}
+
+	idFloat, ok := claims["id"].(float64)
+	if !ok {
+		return 0, errors.New("ID tidak valid dalam token")
+	}
+
+	return uint(idFloat), nil
+}
@@ -0,0 +1,306 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is one entry of the key ring. Its ID is derived from the key
+// material so it stays the same when the key moves from active to previous.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private is nil for keys that can only verify.
+	Private interface{}
+	Public  interface{}
+	// ValidUntil is zero for the active key; previous keys stop verifying
+	// once the grace period has passed.
+	ValidUntil time.Time
+}
+
+type KeyRing struct {
+	Active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+var (
+	keyRingMu sync.RWMutex
+	keyRing   *KeyRing
+)
+
+// InitKeyRing loads signing keys from the environment:
+//
+//	JWT_PRIVATE_KEY_FILE          PEM file with the active RSA or Ed25519 private key
+//	JWT_SECRET_KEY                HS256 secret, used when no private key file is set
+//	JWT_PREVIOUS_KEY_FILES        comma-separated PEM files (private or public) of retired keys
+//	JWT_PREVIOUS_SECRET_KEY_FILES comma-separated files each holding one retired HS256 secret
+//	JWT_KEY_GRACE_PERIOD          how long retired keys still verify, e.g. "24h" (default 24h)
+//
+// Each retired key file is followed by "@" and the RFC 3339 time it was
+// retired, e.g. "keys/old.pem@2026-10-01T09:00:00+07:00". The grace period
+// counts from then, so restarting the server does not extend it. Retired
+// secrets are read from files because a secret may contain any character,
+// including the comma between entries; a trailing newline in the file is
+// not part of the secret.
+//
+// It fails when no active key is configured so the server never signs with
+// an empty secret.
+func InitKeyRing() error {
+	ring := &KeyRing{keys: map[string]*SigningKey{}}
+
+	switch {
+	case os.Getenv("JWT_PRIVATE_KEY_FILE") != "":
+		key, err := loadPEMKey(os.Getenv("JWT_PRIVATE_KEY_FILE"))
+		if err != nil {
+			return err
+		}
+		if key.Private == nil {
+			return errors.New("JWT_PRIVATE_KEY_FILE harus berisi private key")
+		}
+		ring.Active = key
+	case os.Getenv("JWT_SECRET_KEY") != "":
+		ring.Active = hmacKey(os.Getenv("JWT_SECRET_KEY"))
+	default:
+		return errors.New("JWT_PRIVATE_KEY_FILE atau JWT_SECRET_KEY harus diisi")
+	}
+	ring.keys[ring.Active.ID] = ring.Active
+
+	grace := 24 * time.Hour
+	if value := os.Getenv("JWT_KEY_GRACE_PERIOD"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("JWT_KEY_GRACE_PERIOD tidak valid: %w", err)
+		}
+		grace = parsed
+	}
+
+	for _, entry := range splitList(os.Getenv("JWT_PREVIOUS_KEY_FILES")) {
+		path, retiredAt, err := splitRetiredAt(entry, "JWT_PREVIOUS_KEY_FILES")
+		if err != nil {
+			return err
+		}
+		key, err := loadPEMKey(path)
+		if err != nil {
+			return err
+		}
+		key.Private = nil
+		key.ValidUntil = retiredAt.Add(grace)
+		ring.add(key)
+	}
+	for _, entry := range splitList(os.Getenv("JWT_PREVIOUS_SECRET_KEY_FILES")) {
+		path, retiredAt, err := splitRetiredAt(entry, "JWT_PREVIOUS_SECRET_KEY_FILES")
+		if err != nil {
+			return err
+		}
+		secret, err := loadSecret(path)
+		if err != nil {
+			return err
+		}
+		key := hmacKey(secret)
+		key.Private = nil
+		key.ValidUntil = retiredAt.Add(grace)
+		ring.add(key)
+	}
+
+	keyRingMu.Lock()
+	keyRing = ring
+	keyRingMu.Unlock()
+	return nil
+}
+
+func (r *KeyRing) add(key *SigningKey) {
+	if _, exists := r.keys[key.ID]; !exists {
+		r.keys[key.ID] = key
+	}
+}
+
+func currentKeyRing() (*KeyRing, error) {
+	keyRingMu.RLock()
+	defer keyRingMu.RUnlock()
+	if keyRing == nil {
+		return nil, errors.New("key ring belum diinisialisasi")
+	}
+	return keyRing, nil
+}
+
+func signToken(claims jwt.MapClaims) (string, error) {
+	ring, err := currentKeyRing()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(ring.Active.Method, claims)
+	token.Header["kid"] = ring.Active.ID
+	return token.SignedString(ring.Active.Private)
+}
+
+// verificationKey is the jwt.Keyfunc used for every token. It picks the key
+// by kid and insists on that key's algorithm, so an RS256 public key can
+// never be used as an HS256 secret.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	ring, err := currentKeyRing()
+	if err != nil {
+		return nil, err
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ring.keys[kid]
+	if !ok {
+		return nil, errors.New("kid tidak dikenal")
+	}
+	if !key.ValidUntil.IsZero() && time.Now().After(key.ValidUntil) {
+		return nil, errors.New("kunci sudah tidak berlaku")
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("algoritma token tidak sesuai")
+	}
+
+	return key.Public, nil
+}
+
+func hmacKey(secret string) *SigningKey {
+	sum := sha256.Sum256([]byte(secret))
+	return &SigningKey{
+		ID:      "hs-" + hex.EncodeToString(sum[:8]),
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+}
+
+func loadSecret(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca kunci %s: %w", path, err)
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("%s tidak berisi secret", path)
+	}
+	return secret, nil
+}
+
+func loadPEMKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca kunci %s: %w", path, err)
+	}
+
+	var private crypto.Signer
+	var public interface{}
+	var method jwt.SigningMethod
+
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		private, public, method = key, &key.PublicKey, jwt.SigningMethodRS256
+	} else if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		signer := key.(crypto.Signer)
+		private, public, method = signer, signer.Public(), jwt.SigningMethodEdDSA
+	} else if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		public, method = key, jwt.SigningMethodRS256
+	} else if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		public, method = key, jwt.SigningMethodEdDSA
+	} else {
+		return nil, fmt.Errorf("%s bukan kunci RSA atau Ed25519 yang valid", path)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(der)
+
+	return &SigningKey{
+		ID:      hex.EncodeToString(sum[:8]),
+		Method:  method,
+		Private: private,
+		Public:  public,
+	}, nil
+}
+
+// splitRetiredAt splits a retired key entry of the form "path@retired_at".
+// The path itself may contain "@", so the time is taken after the last one.
+func splitRetiredAt(entry, variable string) (string, time.Time, error) {
+	i := strings.LastIndex(entry, "@")
+	if i < 0 {
+		return "", time.Time{}, fmt.Errorf("%s: setiap kunci lama harus diikuti @waktu_pensiun", variable)
+	}
+	retiredAt, err := time.Parse(time.RFC3339, entry[i+1:])
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: waktu pensiun tidak valid: %w", variable, err)
+	}
+	return entry[:i], retiredAt, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// JWK is the public part of an asymmetric signing key in RFC 7517 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWKS lists the asymmetric keys that currently verify tokens. HS256
+// secrets are never published.
+func PublicJWKS() (JWKSet, error) {
+	ring, err := currentKeyRing()
+	if err != nil {
+		return JWKSet{}, err
+	}
+
+	set := JWKSet{Keys: []JWK{}}
+	now := time.Now()
+	for _, key := range ring.keys {
+		if !key.ValidUntil.IsZero() && now.After(key.ValidUntil) {
+			continue
+		}
+
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
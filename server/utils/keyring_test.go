@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInitKeyRingReadsRetiredSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "old_secret")
+	secret := "s3cret,with@commas,and@signs"
+	if err := os.WriteFile(path, []byte(secret+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	retiredAt := time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+	t.Setenv("JWT_PRIVATE_KEY_FILE", "")
+	t.Setenv("JWT_SECRET_KEY", "current-secret")
+	t.Setenv("JWT_PREVIOUS_KEY_FILES", "")
+	t.Setenv("JWT_PREVIOUS_SECRET_KEY_FILES", path+"@"+retiredAt.Format(time.RFC3339))
+	t.Setenv("JWT_KEY_GRACE_PERIOD", "1h")
+
+	if err := InitKeyRing(); err != nil {
+		t.Fatalf("InitKeyRing: %v", err)
+	}
+	ring, err := currentKeyRing()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := hmacKey(secret)
+	key, ok := ring.keys[want.ID]
+	if !ok {
+		t.Fatalf("retired secret %s missing from the key ring", want.ID)
+	}
+	if string(key.Public.([]byte)) != secret {
+		t.Errorf("secret = %q, want %q", key.Public, secret)
+	}
+	if key.Private != nil {
+		t.Error("retired secret can still sign")
+	}
+	if !key.ValidUntil.Equal(retiredAt.Add(time.Hour)) {
+		t.Errorf("ValidUntil = %v, want %v", key.ValidUntil, retiredAt.Add(time.Hour))
+	}
+}
+
+func TestInitKeyRingRejectsEmptySecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JWT_PRIVATE_KEY_FILE", "")
+	t.Setenv("JWT_SECRET_KEY", "current-secret")
+	t.Setenv("JWT_PREVIOUS_KEY_FILES", "")
+	t.Setenv("JWT_PREVIOUS_SECRET_KEY_FILES", path+"@2026-10-01T09:00:00+07:00")
+
+	if err := InitKeyRing(); err == nil {
+		t.Fatal("InitKeyRing accepted an empty secret file")
+	}
+}
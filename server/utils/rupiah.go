@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNoRupiahAmount = errors.New("teks tidak memuat nominal rupiah")
+
+// FormatRupiah renders an amount in Indonesian notation, such as
+// "Rp 1.250.000".
+func FormatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return sign + "Rp " + groupThousands(strconv.FormatInt(amount, 10))
+}
+
+// FormatRupiahFrom renders a starting price, such as
+// "Mulai dari Rp 1.250.000".
+func FormatRupiahFrom(amount int64) string {
+	return "Mulai dari " + FormatRupiah(amount)
+}
+
+// FormatRupiahDiscount renders a sale price with its discount off the
+// original price, such as "Rp 800.000 (diskon 20%)".
+func FormatRupiahDiscount(original, sale int64) string {
+	percent := DiscountPercent(original, sale)
+	if percent == 0 {
+		return FormatRupiah(sale)
+	}
+	return FormatRupiah(sale) + " (diskon " + strconv.Itoa(percent) + "%)"
+}
+
+// DiscountPercent is the whole percentage sale is below original, rounded
+// down so a discount is never overstated. It is 0 when sale is not lower.
+func DiscountPercent(original, sale int64) int {
+	if original <= 0 || sale < 0 || sale >= original {
+		return 0
+	}
+	return int((original - sale) * 100 / original)
+}
+
+func groupThousands(digits string) string {
+	var out strings.Builder
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteByte('.')
+		}
+		out.WriteByte(digits[i])
+	}
+	return out.String()
+}
+
+// ParsedRupiah is a price label read back by ParseRupiah.
+type ParsedRupiah struct {
+	Amount int64
+	// From is set for starting prices ("mulai dari Rp 1.250.000").
+	From bool
+	// Plain is set when the label holds nothing besides the amount, so
+	// rendering Amount again loses nothing.
+	Plain bool
+}
+
+var (
+	rupiahFromPrefix = regexp.MustCompile(`^(mulai\s+dari|mulai|dari|start\s+from|from)\s*[:]?\s*`)
+	rupiahCurrency   = regexp.MustCompile(`^(rp\.?|idr)\s*`)
+	rupiahAmount     = regexp.MustCompile(`(\d[\d.,]*)\s*(ribu|rb|k|juta|jt)?\b`)
+	rupiahTrailer    = regexp.MustCompile(`^\s*(,-|,00|-)?\s*$`)
+)
+
+// ParseRupiah reads the first amount out of a free-text price label. It
+// understands dot thousands separators, a comma or dot before decimals, and
+// the "rb"/"ribu" and "jt"/"juta" suffixes. Amounts are rounded to whole
+// rupiah.
+func ParseRupiah(text string) (ParsedRupiah, error) {
+	var parsed ParsedRupiah
+
+	rest := strings.ToLower(strings.TrimSpace(text))
+	if prefix := rupiahFromPrefix.FindString(rest); prefix != "" {
+		parsed.From = true
+		rest = rest[len(prefix):]
+	}
+	rest = rupiahCurrency.ReplaceAllString(rest, "")
+
+	match := rupiahAmount.FindStringSubmatchIndex(rest)
+	if match == nil {
+		return parsed, ErrNoRupiahAmount
+	}
+	number := rest[match[2]:match[3]]
+	suffix := ""
+	if match[4] >= 0 {
+		suffix = rest[match[4]:match[5]]
+	}
+
+	amount, whole, err := parseRupiahNumber(number, suffix)
+	if err != nil {
+		return parsed, err
+	}
+	parsed.Amount = amount
+	parsed.Plain = whole && match[0] == 0 && rupiahTrailer.MatchString(rest[match[1]:])
+	return parsed, nil
+}
+
+// parseRupiahNumber also reports whether the amount was whole rupiah before
+// rounding.
+func parseRupiahNumber(number, suffix string) (int64, bool, error) {
+	number = strings.TrimRight(number, ".,")
+
+	multiplier := 1.0
+	switch suffix {
+	case "ribu", "rb", "k":
+		multiplier = 1e3
+	case "juta", "jt":
+		multiplier = 1e6
+	}
+
+	// The last separator starts the decimals when it is a comma, or when a
+	// suffix follows and fewer than three digits come after it ("1,5 jt",
+	// "2.5jt"). Every other separator groups thousands.
+	integer, decimals := number, ""
+	if i := strings.LastIndexAny(number, ".,"); i >= 0 {
+		tail := number[i+1:]
+		if number[i] == ',' && len(tail) != 3 || suffix != "" && len(tail) < 3 {
+			integer, decimals = number[:i], tail
+		}
+	}
+	integer = strings.NewReplacer(".", "", ",", "").Replace(integer)
+
+	value, err := strconv.ParseFloat(integer+"."+decimals+"0", 64)
+	if err != nil {
+		return 0, false, ErrNoRupiahAmount
+	}
+	value *= multiplier
+	rounded := math.Round(value)
+	return int64(rounded), rounded == value, nil
+}
@@ -0,0 +1,39 @@
+package utils
+
+import "testing"
+
+func TestParseRupiah(t *testing.T) {
+	tests := []struct {
+		text string
+		want ParsedRupiah
+	}{
+		{"Rp 816.000", ParsedRupiah{Amount: 816000, Plain: true}},
+		{"Rp816.000,-", ParsedRupiah{Amount: 816000, Plain: true}},
+		{"1,5 jt", ParsedRupiah{Amount: 1500000, Plain: true}},
+		{"Rp 1.234,5", ParsedRupiah{Amount: 1235}},
+		{"Rp 5.000k", ParsedRupiah{Amount: 5000000, Plain: true}},
+		{"0", ParsedRupiah{Amount: 0, Plain: true}},
+		{"Mulai dari Rp 1.250.000", ParsedRupiah{Amount: 1250000, From: true, Plain: true}},
+		{"Rp 2.5jt", ParsedRupiah{Amount: 2500000, Plain: true}},
+		{"Rp 750rb / gram", ParsedRupiah{Amount: 750000}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRupiah(tt.text)
+		if err != nil {
+			t.Errorf("ParseRupiah(%q) error: %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRupiah(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseRupiahWithoutAmount(t *testing.T) {
+	for _, text := range []string{"", "harga nego", "Rp -"} {
+		if _, err := ParseRupiah(text); err != ErrNoRupiahAmount {
+			t.Errorf("ParseRupiah(%q) error = %v, want ErrNoRupiahAmount", text, err)
+		}
+	}
+}
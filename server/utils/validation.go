@@ -1,6 +1,15 @@
 package utils
 
-import  "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the shortest password an admin may choose, and the
+// shortest DEFAULT_ADMIN_PASSWORD accepted for the seeded admin.
+const MinPasswordLength = 12
 
 func HashPassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -10,4 +19,12 @@ func HashPassword(password string) (string, error) {
 func CheckPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
+
+// HashToken returns the SHA-256 hex digest of an opaque token. Unlike
+// passwords these tokens are high-entropy, so a fast hash is enough and keeps
+// lookups by hash possible.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}